@@ -0,0 +1,172 @@
+package fin
+
+import (
+	"errors"
+	"math/big"
+)
+
+// AmortizationPeriod is a period of an amortization schedule. As in the TVM functions, payments are negative when the present value is positive.
+type AmortizationPeriod struct {
+	// Period is the number of the period, starting at 1
+	Period    int
+	Payment   Decimal
+	Interest  Decimal
+	Principal Decimal
+	// Balance is the outstanding balance at the end of the period
+	Balance Decimal
+}
+
+// PresentValueDecimal returns the Present Value of a cash flow with constant payments and interest rate (annuities), computed exactly and
+// rounded to scale digits after the decimal point (see MinorUnits) with the rounding mode (see the Round* constants).
+//
+// Excel equivalent: PV
+func PresentValueDecimal(rate Decimal, numPeriods int, pmt Decimal, fv Decimal, paymentType int, scale int, mode int) (Decimal, error) {
+	if err := validateDecimalTVM(numPeriods, paymentType, mode); err != nil {
+		return Decimal{}, err
+	}
+	r := rate.rat()
+	if r.Sign() == 0 {
+		pv := new(big.Rat).Mul(pmt.rat(), new(big.Rat).SetInt64(int64(numPeriods)))
+		return roundRat(pv.Neg(pv.Add(pv, fv.rat())), scale, mode), nil
+	}
+	compounded := ratPow(new(big.Rat).Add(big.NewRat(1, 1), r), numPeriods)
+	// pv = -(pmt annuity + fv) / compounded
+	pv := new(big.Rat).Mul(pmt.rat(), annuityFactor(r, compounded, paymentType))
+	pv.Add(pv, fv.rat())
+	pv.Quo(pv, compounded)
+	return roundRat(pv.Neg(pv), scale, mode), nil
+}
+
+// FutureValueDecimal returns the Future Value of a cash flow with constant payments and interest rate (annuities), computed exactly and
+// rounded to scale digits after the decimal point (see MinorUnits) with the rounding mode (see the Round* constants).
+//
+// Excel equivalent: FV
+func FutureValueDecimal(rate Decimal, numPeriods int, pmt Decimal, pv Decimal, paymentType int, scale int, mode int) (Decimal, error) {
+	if err := validateDecimalTVM(numPeriods, paymentType, mode); err != nil {
+		return Decimal{}, err
+	}
+	r := rate.rat()
+	if r.Sign() == 0 {
+		fv := new(big.Rat).Mul(pmt.rat(), new(big.Rat).SetInt64(int64(numPeriods)))
+		return roundRat(fv.Neg(fv.Add(fv, pv.rat())), scale, mode), nil
+	}
+	compounded := ratPow(new(big.Rat).Add(big.NewRat(1, 1), r), numPeriods)
+	// fv = -(pv compounded + pmt annuity)
+	fv := new(big.Rat).Mul(pmt.rat(), annuityFactor(r, compounded, paymentType))
+	fv.Add(fv, new(big.Rat).Mul(pv.rat(), compounded))
+	return roundRat(fv.Neg(fv), scale, mode), nil
+}
+
+// PaymentDecimal returns the constant payment (annuity) for a cash flow with a constant interest rate, computed exactly and
+// rounded to scale digits after the decimal point (see MinorUnits) with the rounding mode (see the Round* constants).
+//
+// Excel equivalent: PMT
+func PaymentDecimal(rate Decimal, numPeriods int, pv Decimal, fv Decimal, paymentType int, scale int, mode int) (Decimal, error) {
+	if err := validateDecimalTVM(numPeriods, paymentType, mode); err != nil {
+		return Decimal{}, err
+	}
+	if numPeriods == 0 {
+		return Decimal{}, errors.New("number of periods must be greater than zero")
+	}
+	r := rate.rat()
+	if r.Sign() == 0 {
+		pmt := new(big.Rat).Add(pv.rat(), fv.rat())
+		pmt.Quo(pmt, new(big.Rat).SetInt64(int64(numPeriods)))
+		return roundRat(pmt.Neg(pmt), scale, mode), nil
+	}
+	compounded := ratPow(new(big.Rat).Add(big.NewRat(1, 1), r), numPeriods)
+	// pmt = -(fv + pv compounded) / annuity
+	pmt := new(big.Rat).Mul(pv.rat(), compounded)
+	pmt.Add(pmt, fv.rat())
+	pmt.Quo(pmt, annuityFactor(r, compounded, paymentType))
+	return roundRat(pmt.Neg(pmt), scale, mode), nil
+}
+
+// AmortizationSchedule returns the schedule of a loan with constant payments, where every amount is rounded to scale digits after the decimal point
+// (see MinorUnits) with the rounding mode (see the Round* constants), as a ledger would record it.
+//
+// The payment is PaymentDecimal's, and each period's interest is the rounded interest on the outstanding balance, so the balance is always exact.
+// The last period absorbs the accumulated rounding: its payment is adjusted so that the final balance is exactly -fv (zero for a fully amortizing loan).
+func AmortizationSchedule(rate Decimal, numPeriods int, pv Decimal, fv Decimal, paymentType int, scale int, mode int) ([]AmortizationPeriod, error) {
+	payment, err := PaymentDecimal(rate, numPeriods, pv, fv, paymentType, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	schedule := make([]AmortizationPeriod, numPeriods)
+	balance := roundRat(pv.rat(), scale, mode)
+	for i := 1; i <= numPeriods; i++ {
+		period := AmortizationPeriod{Period: i, Payment: payment, Interest: NewDecimal(0, scale)}
+		// in first period of advanced payments no interests are paid
+		if paymentType == PayEnd || i > 1 {
+			period.Interest = roundRat(balance.Mul(rate).Neg().rat(), scale, mode)
+		}
+		period.Principal = period.Payment.Sub(period.Interest)
+		if i == numPeriods {
+			period.Principal = roundRat(fv.rat(), scale, mode).Neg().Sub(balance)
+			period.Payment = period.Principal.Add(period.Interest)
+		}
+		balance = balance.Add(period.Principal)
+		period.Balance = balance
+		schedule[i-1] = period
+	}
+	return schedule, nil
+}
+
+// InterestPaymentDecimal returns the interest payment for a given period of the AmortizationSchedule of a cash flow with constant periodic payments (annuities).
+//
+// Excel equivalent: IPMT
+func InterestPaymentDecimal(rate Decimal, period int, numPeriods int, pv Decimal, fv Decimal, paymentType int, scale int, mode int) (Decimal, error) {
+	schedule, err := amortizationPeriod(rate, period, numPeriods, pv, fv, paymentType, scale, mode)
+	return schedule.Interest, err
+}
+
+// PrincipalPaymentDecimal returns the principal payment for a given period of the AmortizationSchedule of a cash flow with constant periodic payments (annuities).
+//
+// Excel equivalent: PPMT
+func PrincipalPaymentDecimal(rate Decimal, period int, numPeriods int, pv Decimal, fv Decimal, paymentType int, scale int, mode int) (Decimal, error) {
+	schedule, err := amortizationPeriod(rate, period, numPeriods, pv, fv, paymentType, scale, mode)
+	return schedule.Principal, err
+}
+
+func amortizationPeriod(rate Decimal, period int, numPeriods int, pv Decimal, fv Decimal, paymentType int, scale int, mode int) (AmortizationPeriod, error) {
+	if period < 1 || period > numPeriods {
+		return AmortizationPeriod{}, errors.New("period must be between one and the number of periods")
+	}
+	schedule, err := AmortizationSchedule(rate, numPeriods, pv, fv, paymentType, scale, mode)
+	if err != nil {
+		return AmortizationPeriod{}, err
+	}
+	return schedule[period-1], nil
+}
+
+// annuityFactor returns (1 + rate paymentType) (compounded - 1) / rate, the future value of a unit payment per period
+func annuityFactor(rate *big.Rat, compounded *big.Rat, paymentType int) *big.Rat {
+	factor := new(big.Rat).Sub(compounded, big.NewRat(1, 1))
+	factor.Quo(factor, rate)
+	if paymentType == PayBegin {
+		factor.Mul(factor, new(big.Rat).Add(big.NewRat(1, 1), rate))
+	}
+	return factor
+}
+
+// ratPow returns x^n, for n >= 0
+func ratPow(x *big.Rat, n int) *big.Rat {
+	result, base := big.NewRat(1, 1), new(big.Rat).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+	}
+	return result
+}
+
+func validateDecimalTVM(numPeriods int, paymentType int, mode int) error {
+	if numPeriods < 0 {
+		return errors.New("number of periods must be positive")
+	}
+	if paymentType != PayEnd && paymentType != PayBegin {
+		return errors.New("payment type must be pay-end or pay-begin")
+	}
+	return validateRoundingMode(mode)
+}
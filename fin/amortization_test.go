@@ -0,0 +1,130 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func decimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestPresentValueDecimal(t *testing.T) {
+	if got, err := PresentValueDecimal(decimal("0.08"), 20, decimal("500"), Decimal{}, PayEnd, 2, RoundHalfUp); err != nil || got.String() != "-4909.07" {
+		t.Errorf("PresentValueDecimal() = %s, %v", got, err)
+	}
+	if got, err := PresentValueDecimal(Decimal{}, 7, decimal("100"), Decimal{}, PayEnd, 2, RoundHalfUp); err != nil || got.String() != "-700.00" {
+		t.Errorf("PresentValueDecimal() with a zero rate = %s, %v", got, err)
+	}
+	if _, err := PresentValueDecimal(decimal("0.29"), -7, decimal("100"), Decimal{}, PayEnd, 2, RoundHalfUp); err == nil {
+		t.Error("A negative number of periods should produce an error")
+	}
+}
+
+func TestFutureValueDecimal(t *testing.T) {
+	if got, err := FutureValueDecimal(decimal("0.005"), 10, decimal("-200"), decimal("-500"), PayBegin, 2, RoundHalfUp); err != nil || got.String() != "2581.40" {
+		t.Errorf("FutureValueDecimal() = %s, %v", got, err)
+	}
+	if _, err := FutureValueDecimal(decimal("0.005"), 10, decimal("-200"), decimal("-500"), 3, 2, RoundHalfUp); err == nil {
+		t.Error("An invalid payment type should return an error")
+	}
+}
+
+func TestPaymentDecimal(t *testing.T) {
+	var tests = []struct {
+		rate        string
+		numPeriods  int
+		pv          string
+		fv          string
+		paymentType int
+		want        string
+	}{
+		{"0.005", 36, "10000", "0", PayEnd, "-304.22"},
+		{"0.00666666666666", 10, "10000", "0", PayEnd, "-1037.03"},
+		{"0.005", 216, "0", "50000", PayEnd, "-129.08"},
+		{"0", 4, "1000", "0", PayEnd, "-250.00"},
+	}
+
+	for _, test := range tests {
+		if got, err := PaymentDecimal(decimal(test.rate), test.numPeriods, decimal(test.pv), decimal(test.fv), test.paymentType, 2, RoundHalfUp); err != nil || got.String() != test.want {
+			t.Errorf("PaymentDecimal(%s, %d, %s, %s, %d, 2, RoundHalfUp) = %s, %v", test.rate, test.numPeriods, test.pv, test.fv, test.paymentType, got, err)
+		}
+	}
+
+	if _, err := PaymentDecimal(decimal("0.005"), 0, decimal("1000"), Decimal{}, PayEnd, 2, RoundHalfUp); err == nil {
+		t.Error("A zero number of periods should produce an error")
+	}
+}
+
+func TestAmortizationSchedule(t *testing.T) {
+	schedule, err := AmortizationSchedule(decimal("0.005"), 36, decimal("10000"), Decimal{}, PayEnd, 2, RoundHalfUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		period    int
+		payment   string
+		interest  string
+		principal string
+		balance   string
+	}{
+		{1, "-304.22", "-50.00", "-254.22", "9745.78"},
+		{2, "-304.22", "-48.73", "-255.49", "9490.29"},
+		{36, "-304.18", "-1.51", "-302.67", "0.00"},
+	}
+	for _, test := range tests {
+		got := schedule[test.period-1]
+		if got.Period != test.period || got.Payment.String() != test.payment || got.Interest.String() != test.interest ||
+			got.Principal.String() != test.principal || got.Balance.String() != test.balance {
+			t.Errorf("AmortizationSchedule() period %d = %+v", test.period, got)
+		}
+	}
+
+	// every period reconciles, and the principal adds up to the loan
+	principal := Decimal{}
+	for _, period := range schedule {
+		if period.Payment.Cmp(period.Interest.Add(period.Principal)) != 0 {
+			t.Errorf("Period %d: payment %s != interest %s + principal %s", period.Period, period.Payment, period.Interest, period.Principal)
+		}
+		principal = principal.Add(period.Principal)
+	}
+	if principal.String() != "-10000.00" {
+		t.Errorf("The principal adds up to %s", principal)
+	}
+
+	// close to the float64 functions, and with a balloon payment
+	for _, paymentType := range []int{PayEnd, PayBegin} {
+		schedule, err := AmortizationSchedule(decimal("0.0075"), 24, decimal("25000"), decimal("-5000"), paymentType, 2, RoundHalfUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := schedule[23].Balance.String(); got != "5000.00" {
+			t.Errorf("AmortizationSchedule() final balance = %s, want 5000.00", got)
+		}
+		for _, period := range schedule {
+			interest, _ := InterestPayment(0.0075, period.Period, 24, 25000, -5000, paymentType)
+			if math.Abs(interest-period.Interest.Float64()) > 0.05 {
+				t.Errorf("Period %d: interest %s, InterestPayment() = %f", period.Period, period.Interest, interest)
+			}
+		}
+	}
+}
+
+func TestInterestAndPrincipalPaymentDecimal(t *testing.T) {
+	if got, err := InterestPaymentDecimal(decimal("0.005"), 2, 36, decimal("10000"), Decimal{}, PayEnd, 2, RoundHalfUp); err != nil || got.String() != "-48.73" {
+		t.Errorf("InterestPaymentDecimal() = %s, %v", got, err)
+	}
+	if got, err := PrincipalPaymentDecimal(decimal("0.005"), 36, 36, decimal("10000"), Decimal{}, PayEnd, 2, RoundHalfUp); err != nil || got.String() != "-302.67" {
+		t.Errorf("PrincipalPaymentDecimal() = %s, %v", got, err)
+	}
+	if got, err := InterestPaymentDecimal(decimal("0.005"), 1, 36, decimal("10000"), Decimal{}, PayBegin, 2, RoundHalfUp); err != nil || got.Sign() != 0 {
+		t.Errorf("InterestPaymentDecimal() for the first advanced payment = %s, %v", got, err)
+	}
+	if _, err := PrincipalPaymentDecimal(decimal("0.005"), 37, 36, decimal("10000"), Decimal{}, PayEnd, 2, RoundHalfUp); err == nil {
+		t.Error("A period after the last one should return an error")
+	}
+}
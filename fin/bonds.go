@@ -21,11 +21,11 @@ const (
 )
 
 // DaysDifference returns the difference of days between two dates based on a daycount basis.
-// Date1 and date2 are UNIX timestamps (seconds).
+// Date1 and date2 are UNIX timestamps (seconds), whose calendar dates are taken in UTC.
 func DaysDifference(date1 int64, date2 int64, basis int) int {
-	y1, mName1, d1 := time.Unix(date1, 0).Date()
+	y1, mName1, d1 := time.Unix(date1, 0).UTC().Date()
 	m1 := int(mName1)
-	y2, mName2, d2 := time.Unix(date2, 0).Date()
+	y2, mName2, d2 := time.Unix(date2, 0).UTC().Date()
 	m2 := int(mName2)
 	switch basis {
 	case CountNasd:
@@ -64,6 +64,51 @@ func DaysPerYear(year int, basis int) int {
 	return 0
 }
 
+// YearFraction returns the fraction of years between two dates based on a daycount basis.
+// Only the calendar dates are considered (each date in its own location), so the result doesn't depend on time zones or DST transitions.
+// For the actual/actual basis, the days falling in each year are divided by the number of days of that year (ISDA convention).
+func YearFraction(date1 time.Time, date2 time.Time, basis int) (float64, error) {
+	if !isValidBasis(basis) {
+		return 0, errors.New("invalid day count basis")
+	}
+	return yearFraction(date1, date2, basis), nil
+}
+
+func yearFraction(date1 time.Time, date2 time.Time, basis int) float64 {
+	from, to := civilDate(date1), civilDate(date2)
+	switch basis {
+	case CountActualActual:
+		if to.Before(from) {
+			return -yearFraction(to, from, basis)
+		}
+		if from.Year() == to.Year() {
+			return float64(actualDays(from, to)) / float64(DaysPerYear(from.Year(), basis))
+		}
+		nextYear := time.Date(from.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		lastYear := time.Date(to.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return float64(actualDays(from, nextYear))/float64(DaysPerYear(from.Year(), basis)) +
+			float64(to.Year()-from.Year()-1) +
+			float64(actualDays(lastYear, to))/float64(DaysPerYear(to.Year(), basis))
+	case CountActual365:
+		return float64(actualDays(from, to)) / 365
+	}
+	return float64(DaysDifference(from.Unix(), to.Unix(), basis)) / 360
+}
+
+// civilDate returns the calendar date of t (in its own location) at midnight UTC
+func civilDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func actualDays(from time.Time, to time.Time) int {
+	return DaysDifference(from.Unix(), to.Unix(), CountActual365)
+}
+
+func isValidBasis(basis int) bool {
+	return basis >= CountNasd && basis <= CountEuropean
+}
+
 // TBillYield returns the yield for a treasury bill
 //
 // settlement is the unix timestamp (seconds) for the settlement date
@@ -118,9 +163,9 @@ func TBillEquivalentYield(settlement int64, maturity int64, discount float64) (f
 		return 0, errors.New("Maturity must happen before settlement!")
 	}
 	dsm := float64(DaysDifference(settlement, maturity, CountActual365))
-	ySettlement, mNameSettlement, _ := time.Unix(settlement, 0).Date()
+	ySettlement, mNameSettlement, _ := time.Unix(settlement, 0).UTC().Date()
 	mSettlement := int(mNameSettlement)
-	yMaturity, _, _ := time.Unix(maturity, 0).Date()
+	yMaturity, _, _ := time.Unix(maturity, 0).UTC().Date()
 	if dsm <= 182 {
 		// for one half year or less, the bond-equivalent-yield is equivalent to an actual/365 interest rate
 		return 365 * discount / (360 - discount*dsm), nil
@@ -146,7 +191,7 @@ func TBillEquivalentYield(settlement int64, maturity int64, discount float64) (f
 //
 // Excel equivalent: DISC
 func DiscountRate(settlement int64, maturity int64, price float64, redemption float64, basis int) float64 {
-	year, _, _ := time.Unix(settlement, 0).Date()
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
 	daysPerYear := DaysPerYear(year, basis)
 	dsm := DaysDifference(settlement, maturity, basis)
 	return (redemption - price) * float64(daysPerYear) / redemption / float64(dsm)
@@ -164,12 +209,215 @@ func DiscountRate(settlement int64, maturity int64, price float64, redemption fl
 //
 // Excel equivalent: PRICEDISC
 func PriceDiscount(settlement int64, maturity int64, discount float64, redemption float64, basis int) float64 {
-	year, _, _ := time.Unix(settlement, 0).Date()
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
 	daysPerYear := DaysPerYear(year, basis)
 	dsm := DaysDifference(settlement, maturity, basis)
 	return redemption - discount*redemption*float64(dsm)/float64(daysPerYear)
 }
 
+// YieldDiscount returns the annual yield of a discounted bond
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// price is the bond's price per $100 face value
+//
+// redemption is the bond's redemption value per $100 face value
+//
+// Excel equivalent: YIELDDISC
+func YieldDiscount(settlement int64, maturity int64, price float64, redemption float64, basis int) float64 {
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
+	daysPerYear := DaysPerYear(year, basis)
+	dsm := DaysDifference(settlement, maturity, basis)
+	return (redemption - price) / price * float64(daysPerYear) / float64(dsm)
+}
+
+// PriceMaturity returns the price per $100 face value of a security that pays interest at maturity
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// issue is the unix timestamp (seconds) for the issue date
+//
+// rate is the security's interest rate at date of issue
+//
+// yield is the security's annual yield
+//
+// Excel equivalent: PRICEMAT
+func PriceMaturity(settlement int64, maturity int64, issue int64, rate float64, yield float64, basis int) float64 {
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
+	daysPerYear := float64(DaysPerYear(year, basis))
+	dim := float64(DaysDifference(issue, maturity, basis))
+	dsm := float64(DaysDifference(settlement, maturity, basis))
+	accrued := float64(DaysDifference(issue, settlement, basis))
+	return (100+dim/daysPerYear*rate*100)/(1+dsm/daysPerYear*yield) - accrued/daysPerYear*rate*100
+}
+
+// YieldMaturity returns the annual yield of a security that pays interest at maturity
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// issue is the unix timestamp (seconds) for the issue date
+//
+// rate is the security's interest rate at date of issue
+//
+// price is the security's price per $100 face value
+//
+// Excel equivalent: YIELDMAT
+func YieldMaturity(settlement int64, maturity int64, issue int64, rate float64, price float64, basis int) float64 {
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
+	daysPerYear := float64(DaysPerYear(year, basis))
+	dim := float64(DaysDifference(issue, maturity, basis))
+	dsm := float64(DaysDifference(settlement, maturity, basis))
+	accrued := float64(DaysDifference(issue, settlement, basis))
+	dirty := price/100 + accrued/daysPerYear*rate
+	return (1 + dim/daysPerYear*rate - dirty) / dirty * daysPerYear / dsm
+}
+
+// InterestRate returns the interest rate for a fully invested security
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// investment is the amount invested in the security
+//
+// redemption is the amount to be received at maturity
+//
+// Excel equivalent: INTRATE
+func InterestRate(settlement int64, maturity int64, investment float64, redemption float64, basis int) float64 {
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
+	daysPerYear := DaysPerYear(year, basis)
+	dsm := DaysDifference(settlement, maturity, basis)
+	return (redemption - investment) / investment * float64(daysPerYear) / float64(dsm)
+}
+
+// AmountReceived returns the amount received at maturity for a fully invested security
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// investment is the amount invested in the security
+//
+// discount is the security's discount rate
+//
+// Excel equivalent: RECEIVED
+func AmountReceived(settlement int64, maturity int64, investment float64, discount float64, basis int) float64 {
+	year, _, _ := time.Unix(settlement, 0).UTC().Date()
+	daysPerYear := DaysPerYear(year, basis)
+	dsm := DaysDifference(settlement, maturity, basis)
+	return investment / (1 - discount*float64(dsm)/float64(daysPerYear))
+}
+
+// These constants are used in ConvertYield (parameters "from" and "to"), for specifying the type of yield of a money market instrument:
+const (
+	// Bank discount yield: (face - price) / face * 360 / days
+	YieldTypeDiscount = iota
+	// Money market yield (CD equivalent yield): (face - price) / price * 360 / days
+	YieldTypeMoneyMarket
+	// Bond-equivalent yield: semiannually compounded yield over a 365-day year, as in TBillEquivalentYield
+	YieldTypeBondEquivalent
+)
+
+// ZeroCouponPrice returns the price per $100 face value of a zero-coupon bond
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// yield is the bond's yield, using the given compounding (see the Compound* constants). Frequency is the number of compounding periods per year, only used with CompoundPeriodic
+func ZeroCouponPrice(settlement int64, maturity int64, yield float64, compounding int, frequency int, basis int) (float64, error) {
+	years, err := zeroCouponYears(settlement, maturity, compounding, frequency, basis)
+	if err != nil {
+		return 0, err
+	}
+	return 100 / growthFactor(yield, years, compounding, frequency), nil
+}
+
+// ZeroCouponYield returns the yield of a zero-coupon bond, using the given compounding (see the Compound* constants)
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// price is the bond's price per $100 face value. Frequency is the number of compounding periods per year, only used with CompoundPeriodic
+func ZeroCouponYield(settlement int64, maturity int64, price float64, compounding int, frequency int, basis int) (float64, error) {
+	if price <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+	years, err := zeroCouponYears(settlement, maturity, compounding, frequency, basis)
+	if err != nil {
+		return 0, err
+	}
+	return rateFromGrowth(100/price, years, compounding, frequency), nil
+}
+
+// ConvertYield converts the yield of an instrument maturing in the given number of days between the yield types given by the YieldType* constants.
+//
+// Up to 182 days the bond-equivalent yield is a simple actual/365 rate, matching TBillEquivalentYield. Beyond that, it's the yield compounded semiannually.
+func ConvertYield(yield float64, days int, from int, to int) (float64, error) {
+	if days <= 0 {
+		return 0, errors.New("days must be strictly positive")
+	}
+	if from < YieldTypeDiscount || from > YieldTypeBondEquivalent || to < YieldTypeDiscount || to > YieldTypeBondEquivalent {
+		return 0, errors.New("invalid yield type")
+	}
+	t := float64(days)
+	// price per unit of face value
+	var price float64
+	switch from {
+	case YieldTypeDiscount:
+		price = 1 - yield*t/360
+	case YieldTypeMoneyMarket:
+		price = 1 / (1 + yield*t/360)
+	case YieldTypeBondEquivalent:
+		if days <= 182 {
+			price = 1 / (1 + yield*t/365)
+		} else if days <= 365 {
+			price = 1 / ((1 + yield/2) * (1 + yield*(t-182.5)/365))
+		} else {
+			price = math.Pow(1+yield/2, -2*t/365)
+		}
+	}
+	if price <= 0 {
+		return 0, errors.New("the yield implies a negative price")
+	}
+	switch to {
+	case YieldTypeDiscount:
+		return (1 - price) * 360 / t, nil
+	case YieldTypeMoneyMarket:
+		return (1/price - 1) * 360 / t, nil
+	}
+	if days <= 182 {
+		return (1/price - 1) * 365 / t, nil
+	} else if days <= 365 {
+		// solve (1 + y/2)(1 + y(t-182.5)/365) = 1/price
+		a := t/730 - 0.25
+		b := t / 365
+		c := 1 - 1/price
+		return (-b + math.Sqrt(b*b-4*a*c)) / (2 * a), nil
+	}
+	return 2 * (math.Pow(price, -365/(2*t)) - 1), nil
+}
+
+func zeroCouponYears(settlement int64, maturity int64, compounding int, frequency int, basis int) (float64, error) {
+	if settlement >= maturity {
+		return 0, errors.New("maturity must happen after settlement")
+	}
+	if !isValidBasis(basis) {
+		return 0, errors.New("invalid day count basis")
+	}
+	if err := validateCompounding(compounding, frequency); err != nil {
+		return 0, err
+	}
+	return yearFraction(time.Unix(settlement, 0).UTC(), time.Unix(maturity, 0).UTC(), basis), nil
+}
+
 func isLeap(year int) bool {
 	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
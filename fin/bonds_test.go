@@ -118,11 +118,11 @@ func TestPriceDiscount(t *testing.T) {
 		basis      int
 		want       float64
 	}{
-		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountNasd, 99.795833},
+		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountNasd, 99.781250},
 		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountActualActual, 99.799180},
 		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountActual360, 99.795833},
 		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountActual365, 99.798630},
-		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountEuropean, 99.795833},
+		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0525, 100, CountEuropean, 99.781250},
 	}
 
 	for _, test := range tests {
@@ -131,3 +131,206 @@ func TestPriceDiscount(t *testing.T) {
 		}
 	}
 }
+
+func TestYieldDiscount(t *testing.T) {
+	var tests = []struct {
+		settlement int64
+		maturity   int64
+		price      float64
+		redemption float64
+		basis      int
+		want       float64
+	}{
+		{time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 99.795, 100, CountActual360, 0.052823},
+	}
+
+	for _, test := range tests {
+		if got := YieldDiscount(test.settlement, test.maturity, test.price, test.redemption, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("YieldDiscount(%d, %d, %f, %f, %d) = %f", test.settlement, test.maturity, test.price, test.redemption, test.basis, got)
+		}
+	}
+}
+
+func TestPriceMaturity(t *testing.T) {
+	var tests = []struct {
+		settlement int64
+		maturity   int64
+		issue      int64
+		rate       float64
+		yield      float64
+		basis      int
+		want       float64
+	}{
+		{time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.April, 13, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2007, time.November, 11, 0, 0, 0, 0, time.UTC).Unix(), 0.061, 0.061, CountNasd, 99.984499},
+	}
+
+	for _, test := range tests {
+		if got := PriceMaturity(test.settlement, test.maturity, test.issue, test.rate, test.yield, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("PriceMaturity(%d, %d, %d, %f, %f, %d) = %f", test.settlement, test.maturity, test.issue, test.rate, test.yield, test.basis, got)
+		}
+	}
+}
+
+func TestYieldMaturity(t *testing.T) {
+	var tests = []struct {
+		settlement int64
+		maturity   int64
+		issue      int64
+		rate       float64
+		price      float64
+		basis      int
+		want       float64
+	}{
+		{time.Date(2008, time.March, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.November, 3, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2007, time.November, 8, 0, 0, 0, 0, time.UTC).Unix(), 0.0625, 100.0123, CountNasd, 0.060954},
+	}
+
+	for _, test := range tests {
+		if got := YieldMaturity(test.settlement, test.maturity, test.issue, test.rate, test.price, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("YieldMaturity(%d, %d, %d, %f, %f, %d) = %f", test.settlement, test.maturity, test.issue, test.rate, test.price, test.basis, got)
+		}
+	}
+
+	// the yield is the one that prices the security back
+	settlement := time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC).Unix()
+	maturity := time.Date(2008, time.April, 13, 0, 0, 0, 0, time.UTC).Unix()
+	issue := time.Date(2007, time.November, 11, 0, 0, 0, 0, time.UTC).Unix()
+	price := PriceMaturity(settlement, maturity, issue, 0.061, 0.05, CountActualActual)
+	if got := YieldMaturity(settlement, maturity, issue, 0.061, price, CountActualActual); math.Abs(0.05-got) > Precision {
+		t.Errorf("YieldMaturity(%d, %d, %d, %f, %f, %d) = %f", settlement, maturity, issue, 0.061, price, CountActualActual, got)
+	}
+}
+
+func TestInterestRate(t *testing.T) {
+	var tests = []struct {
+		settlement int64
+		maturity   int64
+		investment float64
+		redemption float64
+		basis      int
+		want       float64
+	}{
+		{time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.May, 15, 0, 0, 0, 0, time.UTC).Unix(), 1000000, 1014420, CountActual360, 0.057680},
+	}
+
+	for _, test := range tests {
+		if got := InterestRate(test.settlement, test.maturity, test.investment, test.redemption, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("InterestRate(%d, %d, %f, %f, %d) = %f", test.settlement, test.maturity, test.investment, test.redemption, test.basis, got)
+		}
+	}
+}
+
+func TestAmountReceived(t *testing.T) {
+	var tests = []struct {
+		settlement int64
+		maturity   int64
+		investment float64
+		discount   float64
+		basis      int
+		want       float64
+	}{
+		{time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.May, 15, 0, 0, 0, 0, time.UTC).Unix(), 1000000, 0.0575, CountActual360, 1014584.654407},
+	}
+
+	for _, test := range tests {
+		if got := AmountReceived(test.settlement, test.maturity, test.investment, test.discount, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("AmountReceived(%d, %d, %f, %f, %d) = %f", test.settlement, test.maturity, test.investment, test.discount, test.basis, got)
+		}
+	}
+}
+
+func TestYearFraction(t *testing.T) {
+	date1 := time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC)
+	date2 := time.Date(2009, time.April, 1, 0, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		date1 time.Time
+		date2 time.Time
+		basis int
+		want  float64
+	}{
+		{date1, date2, CountNasd, 1.25},
+		{date1, date2, CountActualActual, 1.246575},
+		{date1, date2, CountActual360, 1.266667},
+		{date1, date2, CountActual365, 1.249315},
+		{date1, date2, CountEuropean, 1.25},
+		{date2, date1, CountActualActual, -1.246575},
+		// only calendar dates matter, regardless of the time of day and the location
+		{time.Date(2008, time.January, 1, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)), time.Date(2009, time.April, 1, 1, 0, 0, 0, time.FixedZone("UTC+9", 9*3600)), CountActual365, 1.249315},
+	}
+
+	for _, test := range tests {
+		if got, _ := YearFraction(test.date1, test.date2, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("YearFraction(%v, %v, %d) = %f", test.date1, test.date2, test.basis, got)
+		}
+	}
+
+	if _, err := YearFraction(date1, date2, 7); err == nil {
+		t.Error("An invalid basis should return an error")
+	}
+}
+
+func TestZeroCouponPrice(t *testing.T) {
+	settlement := time.Date(2020, time.January, 15, 0, 0, 0, 0, time.UTC).Unix()
+	maturity := time.Date(2030, time.January, 15, 0, 0, 0, 0, time.UTC).Unix()
+	var tests = []struct {
+		compounding int
+		frequency   int
+		basis       int
+		want        float64
+	}{
+		{CompoundPeriodic, 1, CountActual365, 61.366711},
+		{CompoundPeriodic, 2, CountActual365, 61.002328},
+		{CompoundContinuous, 0, CountActual365, 60.628145},
+		{CompoundPeriodic, 1, CountNasd, 61.391325},
+	}
+
+	for _, test := range tests {
+		got, _ := ZeroCouponPrice(settlement, maturity, 0.05, test.compounding, test.frequency, test.basis)
+		if math.Abs(test.want-got) > Precision {
+			t.Errorf("ZeroCouponPrice(%d, %d, %f, %d, %d, %d) = %f", settlement, maturity, 0.05, test.compounding, test.frequency, test.basis, got)
+		}
+		if yield, _ := ZeroCouponYield(settlement, maturity, got, test.compounding, test.frequency, test.basis); math.Abs(yield-0.05) > Precision {
+			t.Errorf("ZeroCouponYield(%d, %d, %f, %d, %d, %d) = %f", settlement, maturity, got, test.compounding, test.frequency, test.basis, yield)
+		}
+	}
+
+	if _, err := ZeroCouponPrice(maturity, settlement, 0.05, CompoundContinuous, 0, CountActual365); err == nil {
+		t.Errorf("When the settlement happens after the maturity, an error should be returned")
+	}
+	if _, err := ZeroCouponYield(settlement, maturity, 0, CompoundContinuous, 0, CountActual365); err == nil {
+		t.Errorf("A price that isn't positive should return an error")
+	}
+}
+
+func TestConvertYield(t *testing.T) {
+	var tests = []struct {
+		yield float64
+		days  int
+		from  int
+		to    int
+		want  float64
+	}{
+		{0.0914, 62, YieldTypeDiscount, YieldTypeBondEquivalent, 0.094151},
+		{0.0914, 62, YieldTypeDiscount, YieldTypeMoneyMarket, 0.092862},
+		{0.0914, 256, YieldTypeDiscount, YieldTypeMoneyMarket, 0.097754},
+		{0.0914, 256, YieldTypeDiscount, YieldTypeBondEquivalent, 0.097740},
+		{0.05, 400, YieldTypeDiscount, YieldTypeBondEquivalent, 0.052843},
+		{0.0914, 62, YieldTypeDiscount, YieldTypeDiscount, 0.0914},
+	}
+
+	for _, test := range tests {
+		got, _ := ConvertYield(test.yield, test.days, test.from, test.to)
+		if math.Abs(test.want-got) > Precision {
+			t.Errorf("ConvertYield(%f, %d, %d, %d) = %f", test.yield, test.days, test.from, test.to, got)
+		}
+		if back, _ := ConvertYield(got, test.days, test.to, test.from); math.Abs(back-test.yield) > Precision {
+			t.Errorf("ConvertYield(%f, %d, %d, %d) = %f", got, test.days, test.to, test.from, back)
+		}
+	}
+
+	if _, err := ConvertYield(0.05, 0, YieldTypeDiscount, YieldTypeMoneyMarket); err == nil {
+		t.Error("If days isn't strictly positive, it must return an error")
+	}
+	if _, err := ConvertYield(0.05, 90, YieldTypeDiscount, 5); err == nil {
+		t.Error("An invalid yield type should return an error")
+	}
+}
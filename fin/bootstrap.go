@@ -0,0 +1,385 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// These constants are used when bootstrapping curves (parameter "interpolation"), for specifying how discount factors are interpolated between pillars:
+const (
+	// Linear interpolation of the continuously compounded zero rates
+	InterpolationLinearZero = iota
+	// Linear interpolation of the logarithm of the discount factors (piecewise constant forward rates)
+	InterpolationLogLinearDiscount
+	// Monotone convex interpolation of the forward rates (Hagan-West)
+	InterpolationMonotoneConvex
+)
+
+// CurveQuote is a market quote used to bootstrap a curve. The curve gets one pillar at the maturity of each quote.
+type CurveQuote interface {
+	// pillar returns the date of the curve's pillar determined by the quote
+	pillar() time.Time
+	// residual returns the difference between the value of the quote implied by the curve and its market value
+	residual(curve *BootstrappedCurve) float64
+}
+
+// DepositQuote is the quote of a money market deposit, paying simple interest between Start and Maturity.
+// If Start is the zero time, the deposit starts at the curve's reference date.
+type DepositQuote struct {
+	Start    time.Time
+	Maturity time.Time
+	Rate     float64
+	Basis    int
+}
+
+func (q DepositQuote) pillar() time.Time {
+	return q.Maturity
+}
+
+func (q DepositQuote) residual(curve *BootstrappedCurve) float64 {
+	start := quoteStart(q.Start, curve)
+	return curve.DiscountFactorAt(q.Maturity) - curve.DiscountFactorAt(start)/(1+q.Rate*yearFraction(start, q.Maturity, q.Basis))
+}
+
+// BillQuote is the quote of a Treasury bill maturing at Maturity, settled at the curve's reference date, with the given discount rate.
+// The bill's price is given by TBillPrice.
+type BillQuote struct {
+	Maturity time.Time
+	Discount float64
+}
+
+func (q BillQuote) pillar() time.Time {
+	return q.Maturity
+}
+
+func (q BillQuote) residual(curve *BootstrappedCurve) float64 {
+	price, _ := TBillPrice(curve.reference.Unix(), civilDate(q.Maturity).Unix(), q.Discount)
+	return curve.DiscountFactorAt(q.Maturity) - price/100
+}
+
+// FRAQuote is the quote of a forward rate agreement on the simple rate between Start and End.
+type FRAQuote struct {
+	Start time.Time
+	End   time.Time
+	Rate  float64
+	Basis int
+}
+
+func (q FRAQuote) pillar() time.Time {
+	return q.End
+}
+
+func (q FRAQuote) residual(curve *BootstrappedCurve) float64 {
+	return curve.DiscountFactorAt(q.End) - curve.DiscountFactorAt(q.Start)/(1+q.Rate*yearFraction(q.Start, q.End, q.Basis))
+}
+
+// FuturesQuote is the quote of an interest rate future on the simple rate between Start and End, whose price is 100 minus the rate in percentage.
+// ConvexityAdjustment is subtracted from the futures rate to obtain the forward rate.
+type FuturesQuote struct {
+	Start               time.Time
+	End                 time.Time
+	Price               float64
+	ConvexityAdjustment float64
+	Basis               int
+}
+
+func (q FuturesQuote) pillar() time.Time {
+	return q.End
+}
+
+func (q FuturesQuote) residual(curve *BootstrappedCurve) float64 {
+	rate := (100-q.Price)/100 - q.ConvexityAdjustment
+	return FRAQuote{q.Start, q.End, rate, q.Basis}.residual(curve)
+}
+
+// SwapQuote is the par rate of a swap exchanging fixed payments, Frequency times per year, against a floating rate projected from the same curve.
+// If Start is the zero time, the swap starts at the curve's reference date.
+type SwapQuote struct {
+	Start     time.Time
+	Maturity  time.Time
+	Rate      float64
+	Frequency int
+	Basis     int
+}
+
+func (q SwapQuote) pillar() time.Time {
+	return q.Maturity
+}
+
+func (q SwapQuote) residual(curve *BootstrappedCurve) float64 {
+	start := quoteStart(q.Start, curve)
+	dates, _ := scheduleDates(start, q.Maturity, q.Frequency)
+	annuity := 0.0
+	for i := 1; i < len(dates); i++ {
+		annuity += yearFraction(dates[i-1], dates[i], q.Basis) * curve.DiscountFactorAt(dates[i])
+	}
+	return q.Rate*annuity - (curve.DiscountFactorAt(start) - curve.DiscountFactorAt(q.Maturity))
+}
+
+func quoteStart(start time.Time, curve *BootstrappedCurve) time.Time {
+	if start.IsZero() {
+		return curve.reference
+	}
+	return start
+}
+
+// BootstrappedCurve is a term structure built from market quotes, with one pillar at the maturity of each quote.
+// It reprices all of its quotes exactly.
+type BootstrappedCurve struct {
+	reference     time.Time
+	interpolation int
+	// times and discount factors of the pillars, including the reference date (time 0, discount factor 1)
+	times []float64
+	dfs   []float64
+	// zero rates of the pillars (excluding time 0), used by the linear interpolation
+	zeroRates []float64
+	// forward rates used by the monotone convex interpolation: discrete forwards for each interval, and instantaneous forwards at each pillar
+	discreteForwards []float64
+	forwards         []float64
+}
+
+// BootstrapCurve returns the curve that reprices all the given quotes, with pillars at their maturities.
+// Time on the curve is measured in years from the reference date on an actual/365 basis.
+//
+// interpolation determines how discount factors are interpolated between pillars (see the Interpolation* constants).
+func BootstrapCurve(reference time.Time, quotes []CurveQuote, interpolation int) (*BootstrappedCurve, error) {
+	if interpolation < InterpolationLinearZero || interpolation > InterpolationMonotoneConvex {
+		return nil, errors.New("invalid interpolation")
+	}
+	if len(quotes) == 0 {
+		return nil, errors.New("at least one quote is required")
+	}
+	reference = civilDate(reference)
+	sorted := append([]CurveQuote{}, quotes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].pillar().Before(sorted[j].pillar())
+	})
+
+	curve := &BootstrappedCurve{
+		reference:     reference,
+		interpolation: interpolation,
+		times:         []float64{0},
+		dfs:           []float64{1},
+	}
+	for _, quote := range sorted {
+		if err := validateQuote(quote, reference); err != nil {
+			return nil, err
+		}
+		t := curveTime(reference, quote.pillar())
+		if t <= curve.times[len(curve.times)-1] {
+			return nil, errors.New("quotes must have different maturities, after the reference date")
+		}
+		curve.times = append(curve.times, t)
+		curve.dfs = append(curve.dfs, 1)
+	}
+
+	// Each pass solves the pillars one after the other. With the local interpolations, pillars only depend on the previous ones and a
+	// single pass is exact. The monotone convex interpolation also depends on the following pillar, so passes are repeated until convergence.
+	n := len(sorted)
+	for pass := 0; pass < MaxIterations; pass++ {
+		maxChange := 0.0
+		for k, quote := range sorted {
+			// during the first pass, the pillars not solved yet are left out of the curve
+			size := n + 1
+			if pass == 0 {
+				size = k + 2
+			}
+			previous := curve.dfs[k+1]
+			err := curve.solvePillar(k+1, size, quote)
+			if err != nil {
+				return nil, err
+			}
+			maxChange = math.Max(maxChange, math.Abs(curve.dfs[k+1]-previous))
+		}
+		if pass > 0 && maxChange < brentTolerance {
+			return curve, nil
+		}
+	}
+	return nil, errors.New("the curve didn't converge")
+}
+
+// solvePillar finds the discount factor of pillar i, so that the quote is repriced by the curve made of its first size pillars
+func (c *BootstrappedCurve) solvePillar(i int, size int, quote CurveQuote) error {
+	times, dfs := c.times, c.dfs
+	defer func() {
+		c.times, c.dfs = times, dfs
+		c.prepare()
+	}()
+	t := times[i]
+	function := func(zeroRate float64) float64 {
+		dfs[i] = math.Exp(-zeroRate * t)
+		c.times, c.dfs = times[:size], dfs[:size]
+		c.prepare()
+		return quote.residual(c)
+	}
+	zeroRate, err := brent(function, -1, 1)
+	if err != nil {
+		return errors.New("couldn't find a discount factor repricing the quote maturing at " + quote.pillar().Format("2006-01-02"))
+	}
+	dfs[i] = math.Exp(-zeroRate * t)
+	return nil
+}
+
+// ReferenceDate returns the date from which time is measured on the curve.
+func (c *BootstrappedCurve) ReferenceDate() time.Time {
+	return c.reference
+}
+
+// DiscountFactorAt returns the present value, at the reference date, of one unit paid at date.
+func (c *BootstrappedCurve) DiscountFactorAt(date time.Time) float64 {
+	return c.DiscountFactor(curveTime(c.reference, date))
+}
+
+// DiscountFactor returns the present value of one unit paid at time t.
+func (c *BootstrappedCurve) DiscountFactor(t float64) float64 {
+	if t <= 0 {
+		return 1
+	}
+	n := len(c.times) - 1
+	i := sort.SearchFloat64s(c.times, t)
+	switch c.interpolation {
+	case InterpolationLinearZero:
+		if n == 0 {
+			return 1
+		}
+		return math.Exp(-linearInterpolation(c.times[1:], c.zeroRates, t) * t)
+	case InterpolationLogLinearDiscount:
+		if n == 0 {
+			return 1
+		}
+		if i > n {
+			// extrapolate the last forward rate
+			i = n
+		}
+		t0, t1 := c.times[i-1], c.times[i]
+		logDf := math.Log(c.dfs[i-1]) + (math.Log(c.dfs[i])-math.Log(c.dfs[i-1]))*(t-t0)/(t1-t0)
+		return math.Exp(logDf)
+	case InterpolationMonotoneConvex:
+		if n == 0 {
+			return 1
+		}
+		if i > n {
+			return c.dfs[n] * math.Exp(-c.forwards[n]*(t-c.times[n]))
+		}
+		t0, t1 := c.times[i-1], c.times[i]
+		x := (t - t0) / (t1 - t0)
+		integral := c.discreteForwards[i]*(t-t0) + (t1-t0)*monotoneConvexIntegral(c.forwards[i-1]-c.discreteForwards[i], c.forwards[i]-c.discreteForwards[i], x)
+		return c.dfs[i-1] * math.Exp(-integral)
+	}
+	return 1
+}
+
+// prepare computes the rates needed by the interpolation from the pillars' discount factors
+func (c *BootstrappedCurve) prepare() {
+	n := len(c.times) - 1
+	if c.interpolation == InterpolationLinearZero {
+		c.zeroRates = make([]float64, n)
+		for i := 1; i <= n; i++ {
+			c.zeroRates[i-1] = -math.Log(c.dfs[i]) / c.times[i]
+		}
+		return
+	}
+	if c.interpolation != InterpolationMonotoneConvex {
+		return
+	}
+	c.discreteForwards = make([]float64, n+1)
+	c.forwards = make([]float64, n+1)
+	if n == 0 {
+		return
+	}
+	for i := 1; i <= n; i++ {
+		c.discreteForwards[i] = math.Log(c.dfs[i-1]/c.dfs[i]) / (c.times[i] - c.times[i-1])
+	}
+	if n == 1 {
+		c.forwards[0] = c.discreteForwards[1]
+		c.forwards[1] = c.discreteForwards[1]
+		return
+	}
+	for i := 1; i < n; i++ {
+		c.forwards[i] = (c.times[i]-c.times[i-1])/(c.times[i+1]-c.times[i-1])*c.discreteForwards[i+1] +
+			(c.times[i+1]-c.times[i])/(c.times[i+1]-c.times[i-1])*c.discreteForwards[i]
+	}
+	c.forwards[0] = c.discreteForwards[1] - 0.5*(c.forwards[1]-c.discreteForwards[1])
+	c.forwards[n] = c.discreteForwards[n] - 0.5*(c.forwards[n-1]-c.discreteForwards[n])
+}
+
+// monotoneConvexIntegral returns the integral between 0 and x of the Hagan-West correction g(x) to the discrete forward rate on an interval,
+// where g0 and g1 are the differences between the instantaneous forwards at the ends of the interval and its discrete forward.
+// The integral over the whole interval is zero, so the pillars' discount factors are preserved.
+func monotoneConvexIntegral(g0 float64, g1 float64, x float64) float64 {
+	switch {
+	case g0 == 0 && g1 == 0:
+		return 0
+	case g0 == 0 || g1 == 0 || (g0 < 0 && -0.5*g0 <= g1 && g1 <= -2*g0) || (g0 > 0 && -0.5*g0 >= g1 && g1 >= -2*g0):
+		// quadratic, which also covers a zero correction at one end, where the other cases degenerate to 0/0
+		return g0*(x-2*x*x+x*x*x) + g1*(-x*x+x*x*x)
+	case (g0 < 0 && g1 > -2*g0) || (g0 > 0 && g1 < -2*g0):
+		// flat, then quadratic
+		eta := (g1 + 2*g0) / (g1 - g0)
+		if x <= eta {
+			return g0 * x
+		}
+		return g0*x + (g1-g0)*math.Pow(x-eta, 3)/math.Pow(1-eta, 2)/3
+	case (g0 > 0 && 0 > g1 && g1 > -0.5*g0) || (g0 < 0 && 0 < g1 && g1 < -0.5*g0):
+		// quadratic, then flat
+		eta := 3 * g1 / (g1 - g0)
+		if x < eta {
+			return g1*x + (g0-g1)*(eta-math.Pow(eta-x, 3)/(eta*eta))/3
+		}
+		return g1*x + (g0-g1)*eta/3
+	}
+	// g0 and g1 have the same sign: two quadratics meeting at their minimum (or maximum)
+	eta := g1 / (g1 + g0)
+	a := -g0 * g1 / (g0 + g1)
+	if x < eta {
+		return a*x + (g0-a)*(eta-math.Pow(eta-x, 3)/(eta*eta))/3
+	}
+	return a*x + (g0-a)*eta/3 + (g1-a)*math.Pow(x-eta, 3)/math.Pow(1-eta, 2)/3
+}
+
+func validateQuote(quote CurveQuote, reference time.Time) error {
+	switch q := quote.(type) {
+	case DepositQuote:
+		if !isValidBasis(q.Basis) {
+			return errors.New("invalid day count basis")
+		}
+		if !q.Start.IsZero() && civilDate(q.Start).Before(reference) {
+			return errors.New("quotes can't start before the reference date")
+		}
+	case BillQuote:
+		if _, err := TBillPrice(reference.Unix(), civilDate(q.Maturity).Unix(), q.Discount); err != nil {
+			return err
+		}
+	case FRAQuote:
+		if !isValidBasis(q.Basis) {
+			return errors.New("invalid day count basis")
+		}
+		if civilDate(q.Start).Before(reference) || !q.Start.Before(q.End) {
+			return errors.New("FRAs must start after the reference date and before they end")
+		}
+	case FuturesQuote:
+		if !isValidBasis(q.Basis) {
+			return errors.New("invalid day count basis")
+		}
+		if civilDate(q.Start).Before(reference) || !q.Start.Before(q.End) {
+			return errors.New("futures must start after the reference date and before they end")
+		}
+	case SwapQuote:
+		if !isValidBasis(q.Basis) {
+			return errors.New("invalid day count basis")
+		}
+		if !q.Start.IsZero() && civilDate(q.Start).Before(reference) {
+			return errors.New("quotes can't start before the reference date")
+		}
+		start := q.Start
+		if start.IsZero() {
+			start = reference
+		}
+		if _, err := scheduleDates(start, q.Maturity, q.Frequency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	curveReference = time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)
+	curveQuotes    = []CurveQuote{
+		SwapQuote{Maturity: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), Rate: 0.021, Frequency: 1, Basis: CountNasd},
+		DepositQuote{Maturity: time.Date(2020, time.April, 2, 0, 0, 0, 0, time.UTC), Rate: 0.015, Basis: CountActual360},
+		BillQuote{Maturity: time.Date(2020, time.July, 2, 0, 0, 0, 0, time.UTC), Discount: 0.016},
+		FRAQuote{time.Date(2020, time.July, 2, 0, 0, 0, 0, time.UTC), time.Date(2020, time.October, 2, 0, 0, 0, 0, time.UTC), 0.017, CountActual360},
+		FuturesQuote{time.Date(2020, time.October, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC), 98.2, 0.0002, CountActual360},
+		SwapQuote{Maturity: time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC), Rate: 0.018, Frequency: 1, Basis: CountNasd},
+		SwapQuote{Maturity: time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC), Rate: 0.019, Frequency: 2, Basis: CountNasd},
+		SwapQuote{Maturity: time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC), Rate: 0.024, Frequency: 1, Basis: CountNasd},
+	}
+)
+
+func TestBootstrapCurve(t *testing.T) {
+	for _, interpolation := range []int{InterpolationLinearZero, InterpolationLogLinearDiscount, InterpolationMonotoneConvex} {
+		curve, err := BootstrapCurve(curveReference, curveQuotes, interpolation)
+		if err != nil {
+			t.Fatalf("BootstrapCurve(%v, %v, %d) returned error %v", curveReference, curveQuotes, interpolation, err)
+		}
+		for _, quote := range curveQuotes {
+			if residual := quote.residual(curve); math.Abs(residual) > 1e-10 {
+				t.Errorf("BootstrapCurve with interpolation %d doesn't reprice %+v (residual %g)", interpolation, quote, residual)
+			}
+		}
+
+		// the first pillar only depends on the deposit
+		if got := curve.DiscountFactorAt(time.Date(2020, time.April, 2, 0, 0, 0, 0, time.UTC)); math.Abs(got-1/(1+0.015*91/360)) > 1e-12 {
+			t.Errorf("BootstrapCurve with interpolation %d: DiscountFactorAt(2020-04-02) = %f", interpolation, got)
+		}
+		if got := curve.DiscountFactorAt(time.Date(2020, time.July, 2, 0, 0, 0, 0, time.UTC)); math.Abs(got-(1-0.016*182/360)) > 1e-12 {
+			t.Errorf("BootstrapCurve with interpolation %d: DiscountFactorAt(2020-07-02) = %f", interpolation, got)
+		}
+		if got := curve.DiscountFactor(0); got != 1 {
+			t.Errorf("BootstrapCurve with interpolation %d: DiscountFactor(0) = %f", interpolation, got)
+		}
+	}
+
+	if _, err := BootstrapCurve(curveReference, append(curveQuotes, DepositQuote{Maturity: time.Date(2020, time.April, 2, 0, 0, 0, 0, time.UTC), Rate: 0.016, Basis: CountActual360}), InterpolationLinearZero); err == nil {
+		t.Error("If two quotes have the same maturity, it must return an error")
+	}
+	if _, err := BootstrapCurve(curveReference, curveQuotes, 5); err == nil {
+		t.Error("An invalid interpolation should return an error")
+	}
+	if _, err := BootstrapCurve(curveReference, []CurveQuote{SwapQuote{Maturity: time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC), Rate: 0.018, Frequency: 5, Basis: CountNasd}}, InterpolationLinearZero); err == nil {
+		t.Error("An invalid frequency should return an error")
+	}
+}
+
+func TestBootstrappedCurveInterpolation(t *testing.T) {
+	quotes := []CurveQuote{
+		DepositQuote{Maturity: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), Rate: 0.02, Basis: CountActual365},
+		DepositQuote{Maturity: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), Rate: 0.03, Basis: CountActual365},
+	}
+	reference := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	t1 := curveTime(reference, quotes[0].pillar())
+	t2 := curveTime(reference, quotes[1].pillar())
+	df1, df2 := 1/(1+0.02*t1), 1/(1+0.03*t2)
+	tm := curveTime(reference, time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC))
+	z1, z2 := -math.Log(df1)/t1, -math.Log(df2)/t2
+
+	var tests = []struct {
+		interpolation int
+		want          float64
+	}{
+		{InterpolationLinearZero, math.Exp(-(z1 + (z2-z1)*(tm-t1)/(t2-t1)) * tm)},
+		{InterpolationLogLinearDiscount, math.Exp(math.Log(df1) + (math.Log(df2)-math.Log(df1))*(tm-t1)/(t2-t1))},
+		{InterpolationMonotoneConvex, 0.951507},
+	}
+
+	for _, test := range tests {
+		curve, _ := BootstrapCurve(reference, quotes, test.interpolation)
+		if got := curve.DiscountFactor(tm); math.Abs(test.want-got) > Precision {
+			t.Errorf("BootstrappedCurve with interpolation %d: DiscountFactor(%f) = %f, want %f", test.interpolation, tm, got, test.want)
+		}
+	}
+}
+
+func TestMonotoneConvexIntegral(t *testing.T) {
+	// the correction integrates to zero over the interval in every case
+	for _, g := range [][2]float64{{-1, 1}, {1, -1}, {-1, 3}, {1, -3}, {1, -0.2}, {-1, 0.2}, {1, 2}, {-1, -2}, {0, 1}, {1, 0}, {0, 0}} {
+		if got := monotoneConvexIntegral(g[0], g[1], 1); math.IsNaN(got) || math.Abs(got) > 1e-12 {
+			t.Errorf("monotoneConvexIntegral(%f, %f, 1) = %g", g[0], g[1], got)
+		}
+		for _, x := range []float64{0, 0.25, 0.5, 0.75} {
+			if got := monotoneConvexIntegral(g[0], g[1], x); math.IsNaN(got) || math.IsInf(got, 0) {
+				t.Errorf("monotoneConvexIntegral(%f, %f, %f) = %g", g[0], g[1], x, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package fin
+
+import (
+	"errors"
+	"math"
+)
+
+// brentTolerance determines how close to the root the Brent algorithm should arrive before stopping.
+// It's tighter than Precision so that curves and spreads built with it reprice their inputs exactly.
+const brentTolerance = 1e-12
+
+// epsilon is the machine precision for float64
+const epsilon = 2.220446049250313e-16
+
+// brent returns the root of function within [a, b] using Brent's method, which combines bisection, secant and inverse quadratic interpolation.
+// Unlike newton, it's guaranteed to converge as long as the root is bracketed (function(a) and function(b) have opposite signs).
+func brent(function func(float64) float64, a float64, b float64) (float64, error) {
+	fa, fb := function(a), function(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if fa*fb > 0 {
+		return 0, errors.New("the root isn't bracketed")
+	}
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for i := 0; i < 10*MaxIterations; i++ {
+		if fb*fc > 0 {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := 2*epsilon*math.Abs(b) + 0.5*brentTolerance
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol || fb == 0 {
+			return b, nil
+		}
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// secant
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				// inverse quadratic interpolation
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = m
+			}
+		} else {
+			// bisection
+			d = m
+			e = m
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else if m > 0 {
+			b += tol
+		} else {
+			b -= tol
+		}
+		fb = function(b)
+	}
+	return 0, errors.New("solution didn't converge")
+}
+
+// bracket expands the interval [a, b] geometrically until it contains a root of function, and returns the resulting interval.
+func bracket(function func(float64) float64, a float64, b float64) (float64, float64, error) {
+	fa, fb := function(a), function(b)
+	for i := 0; i < MaxIterations; i++ {
+		if fa*fb <= 0 {
+			return a, b, nil
+		}
+		if math.Abs(fa) < math.Abs(fb) {
+			a += 1.6 * (a - b)
+			fa = function(a)
+		} else {
+			b += 1.6 * (b - a)
+			fb = function(b)
+		}
+	}
+	return 0, 0, errors.New("couldn't bracket the solution")
+}
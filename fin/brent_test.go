@@ -0,0 +1,24 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrent(t *testing.T) {
+	got, err := brent(func(x float64) float64 { return x*x - 2 }, 0, 2)
+	if err != nil || math.Abs(got-math.Sqrt2) > brentTolerance {
+		t.Errorf("brent(x^2-2, 0, 2) = %f, %v", got, err)
+	}
+
+	if _, err := brent(func(x float64) float64 { return x*x + 1 }, 0, 2); err == nil {
+		t.Error("If the root isn't bracketed, it must return an error")
+	}
+}
+
+func TestBracket(t *testing.T) {
+	a, b, err := bracket(func(x float64) float64 { return x - 10 }, 0, 1)
+	if err != nil || a > 10 || b < 10 {
+		t.Errorf("bracket(x-10, 0, 1) = %f, %f, %v", a, b, err)
+	}
+}
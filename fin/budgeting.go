@@ -0,0 +1,188 @@
+package fin
+
+import (
+	"errors"
+	"math"
+)
+
+// ProjectMetrics holds the capital budgeting metrics of a project, as returned by EvaluateProject.
+type ProjectMetrics struct {
+	// NetPresentValue is the NPV of the project, with the initial investment occurring at the start (not discounted)
+	NetPresentValue float64
+	// ProfitabilityIndex is the present value of the future cash flows divided by the initial investment
+	ProfitabilityIndex float64
+	// EquivalentAnnualAnnuity is the constant periodic cash flow having the same NPV as the project
+	EquivalentAnnualAnnuity float64
+	// PaybackPeriod is the number of periods needed to recover the initial investment (+Inf if it's never recovered)
+	PaybackPeriod float64
+	// DiscountedPaybackPeriod is the number of periods needed to recover the initial investment with discounted cash flows (+Inf if it's never recovered)
+	DiscountedPaybackPeriod float64
+}
+
+// EvaluateProject returns the capital budgeting metrics of a project given its cash flow series and discount rate.
+//
+// values[0] is the initial investment (a negative value), occurring at the start of the project. The following values occur at the end of each period.
+func EvaluateProject(rate float64, values []float64) (ProjectMetrics, error) {
+	if err := validateProject(values); err != nil {
+		return ProjectMetrics{}, err
+	}
+	pi, err := ProfitabilityIndex(rate, values)
+	if err != nil {
+		return ProjectMetrics{}, err
+	}
+	eaa, err := EquivalentAnnualAnnuity(rate, values)
+	if err != nil {
+		return ProjectMetrics{}, err
+	}
+	payback, err := PaybackPeriod(values)
+	if err != nil {
+		payback = math.Inf(1)
+	}
+	discountedPayback, err := DiscountedPaybackPeriod(rate, values)
+	if err != nil {
+		discountedPayback = math.Inf(1)
+	}
+	return ProjectMetrics{
+		NetPresentValue:         projectNetPresentValue(rate, values),
+		ProfitabilityIndex:      pi,
+		EquivalentAnnualAnnuity: eaa,
+		PaybackPeriod:           payback,
+		DiscountedPaybackPeriod: discountedPayback,
+	}, nil
+}
+
+// ProfitabilityIndex returns the ratio between the present value of the future cash flows of a project and its initial investment.
+//
+// values[0] is the initial investment (a negative value), occurring at the start of the project. The following values occur at the end of each period.
+func ProfitabilityIndex(rate float64, values []float64) (float64, error) {
+	if err := validateProject(values); err != nil {
+		return 0, err
+	}
+	return NetPresentValue(rate, values[1:]) / -values[0], nil
+}
+
+// EquivalentAnnualAnnuity returns the constant cash flow received at the end of each period having the same NPV as the project.
+// It allows comparing projects with different lives.
+//
+// values[0] is the initial investment (a negative value), occurring at the start of the project. The following values occur at the end of each period.
+func EquivalentAnnualAnnuity(rate float64, values []float64) (float64, error) {
+	if err := validateProject(values); err != nil {
+		return 0, err
+	}
+	return Payment(rate, len(values)-1, -projectNetPresentValue(rate, values), 0, PayEnd)
+}
+
+// EquivalentAnnualCost returns the constant cost incurred at the end of each period having the same present value as the costs of owning an asset.
+// Costs are negative values, and the result is expressed as a positive cost.
+//
+// values[0] is the purchase cost, occurring at the start. The following values occur at the end of each period.
+func EquivalentAnnualCost(rate float64, values []float64) (float64, error) {
+	if err := validateProject(values); err != nil {
+		return 0, err
+	}
+	eaa, err := Payment(rate, len(values)-1, -projectNetPresentValue(rate, values), 0, PayEnd)
+	if err != nil {
+		return 0, err
+	}
+	return -eaa, nil
+}
+
+// PaybackPeriod returns the number of periods needed for the cumulative cash flow of a project to recover its initial investment.
+// Cash flows are assumed to be received uniformly during each period.
+//
+// values[0] is the initial investment (a negative value), occurring at the start of the project. The following values occur at the end of each period.
+func PaybackPeriod(values []float64) (float64, error) {
+	if err := validateProject(values); err != nil {
+		return 0, err
+	}
+	return payback(values)
+}
+
+// DiscountedPaybackPeriod returns the number of periods needed for the cumulative discounted cash flow of a project to recover its initial investment.
+// Cash flows are assumed to be received uniformly during each period.
+//
+// values[0] is the initial investment (a negative value), occurring at the start of the project. The following values occur at the end of each period.
+func DiscountedPaybackPeriod(rate float64, values []float64) (float64, error) {
+	if err := validateProject(values); err != nil {
+		return 0, err
+	}
+	discounted := make([]float64, len(values))
+	for i, value := range values {
+		discounted[i] = value / math.Pow(1+rate, float64(i))
+	}
+	return payback(discounted)
+}
+
+// NetPresentValueProfile returns the NPV of a project for numPoints rates evenly spaced between minRate and maxRate (both included).
+//
+// values[0] is the initial investment, occurring at the start of the project. The following values occur at the end of each period.
+func NetPresentValueProfile(values []float64, minRate float64, maxRate float64, numPoints int) (rates []float64, npvs []float64, err error) {
+	if err := validateProject(values); err != nil {
+		return nil, nil, err
+	}
+	if numPoints < 2 {
+		return nil, nil, errors.New("the number of points must be at least two")
+	}
+	if minRate >= maxRate {
+		return nil, nil, errors.New("minRate must be lower than maxRate")
+	}
+	if minRate <= -1 {
+		return nil, nil, errors.New("rates must be greater than -1")
+	}
+	rates = make([]float64, numPoints)
+	npvs = make([]float64, numPoints)
+	step := (maxRate - minRate) / float64(numPoints-1)
+	for i := 0; i < numPoints; i++ {
+		rates[i] = minRate + float64(i)*step
+		npvs[i] = projectNetPresentValue(rates[i], values)
+	}
+	return rates, npvs, nil
+}
+
+// CrossoverRate returns the discount rate at which two projects have the same NPV, that is the internal rate of return of the difference of their cash flows.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+func CrossoverRate(values1 []float64, values2 []float64, guess float64) (float64, error) {
+	nper := len(values1)
+	if len(values2) > nper {
+		nper = len(values2)
+	}
+	diff := make([]float64, nper)
+	for i := range diff {
+		if i < len(values1) {
+			diff[i] += values1[i]
+		}
+		if i < len(values2) {
+			diff[i] -= values2[i]
+		}
+	}
+	if min, max := minMaxSlice(diff); min*max >= 0 {
+		return 0, errors.New("the projects' NPV profiles don't cross")
+	}
+	return InternalRateOfReturn(diff, guess)
+}
+
+// projectNetPresentValue returns the NPV of a cash flow series whose first value occurs at the start (not discounted)
+func projectNetPresentValue(rate float64, values []float64) float64 {
+	return values[0] + NetPresentValue(rate, values[1:])
+}
+
+func payback(values []float64) (float64, error) {
+	cumulative := values[0]
+	for i := 1; i < len(values); i++ {
+		if cumulative+values[i] >= 0 && values[i] > 0 {
+			return float64(i-1) + -cumulative/values[i], nil
+		}
+		cumulative += values[i]
+	}
+	return 0, errors.New("the initial investment is never recovered")
+}
+
+func validateProject(values []float64) error {
+	if len(values) < 2 {
+		return errors.New("the cash flow must contain at least two values")
+	}
+	if values[0] >= 0 {
+		return errors.New("the initial investment must be negative")
+	}
+	return nil
+}
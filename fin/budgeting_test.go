@@ -0,0 +1,106 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluateProject(t *testing.T) {
+	values := []float64{-10000, 3000, 4200, 6800}
+	got, err := EvaluateProject(0.1, values)
+	if err != nil {
+		t.Fatalf("EvaluateProject(%f, %v) returned error %v", 0.1, values, err)
+	}
+	want := ProjectMetrics{1307.287754, 1.130729, 525.679758, 2.411765, 2.744118}
+	if math.Abs(got.NetPresentValue-want.NetPresentValue) > Precision ||
+		math.Abs(got.ProfitabilityIndex-want.ProfitabilityIndex) > Precision ||
+		math.Abs(got.EquivalentAnnualAnnuity-want.EquivalentAnnualAnnuity) > Precision ||
+		math.Abs(got.PaybackPeriod-want.PaybackPeriod) > Precision ||
+		math.Abs(got.DiscountedPaybackPeriod-want.DiscountedPaybackPeriod) > Precision {
+		t.Errorf("EvaluateProject(%f, %v) = %+v", 0.1, values, got)
+	}
+
+	got, _ = EvaluateProject(0.1, []float64{-10000, 3000, 3000})
+	if !math.IsInf(got.PaybackPeriod, 1) || !math.IsInf(got.DiscountedPaybackPeriod, 1) {
+		t.Errorf("When the investment is never recovered, the payback periods should be infinite")
+	}
+
+	if _, err := EvaluateProject(0.1, []float64{10000, 3000}); err == nil {
+		t.Error("If the initial investment isn't negative, it must return an error")
+	}
+}
+
+func TestProfitabilityIndex(t *testing.T) {
+	var tests = []struct {
+		rate   float64
+		values []float64
+		want   float64
+	}{
+		{0.1, []float64{-10000, 3000, 4200, 6800}, 1.130729},
+		{0, []float64{-10000, 3000, 4200, 6800}, 1.4},
+	}
+
+	for _, test := range tests {
+		if got, _ := ProfitabilityIndex(test.rate, test.values); math.Abs(test.want-got) > Precision {
+			t.Errorf("ProfitabilityIndex(%f, %v) = %f", test.rate, test.values, got)
+		}
+	}
+
+	if _, err := ProfitabilityIndex(0.1, []float64{-10000}); err == nil {
+		t.Error("If the cash flow has less than two values, it must return an error")
+	}
+}
+
+func TestEquivalentAnnualCost(t *testing.T) {
+	values := []float64{-10000, -500, -500, -500, -500, -500}
+	if got, _ := EquivalentAnnualCost(0.1, values); math.Abs(got-3137.974808) > Precision {
+		t.Errorf("EquivalentAnnualCost(%f, %v) = %f", 0.1, values, got)
+	}
+
+	if _, err := EquivalentAnnualCost(0.1, []float64{-10000}); err == nil {
+		t.Error("If the cash flow has less than two values, it must return an error")
+	}
+}
+
+func TestNetPresentValueProfile(t *testing.T) {
+	values := []float64{-10000, 3000, 4200, 6800}
+	rates, npvs, err := NetPresentValueProfile(values, 0, 0.1, 3)
+	if err != nil {
+		t.Fatalf("NetPresentValueProfile returned error %v", err)
+	}
+	wantRates := []float64{0, 0.05, 0.1}
+	wantNpvs := []float64{4000, 2540.762337, 1307.287754}
+	for i := range wantRates {
+		if math.Abs(rates[i]-wantRates[i]) > Precision || math.Abs(npvs[i]-wantNpvs[i]) > Precision {
+			t.Errorf("NetPresentValueProfile(%v, 0, 0.1, 3) = %v, %v", values, rates, npvs)
+		}
+	}
+
+	if _, _, err := NetPresentValueProfile(values, 0.1, 0, 3); err == nil {
+		t.Error("If minRate isn't lower than maxRate, it must return an error")
+	}
+
+	if _, _, err := NetPresentValueProfile(nil, 0, 0.1, 3); err == nil {
+		t.Error("If the cash flow is empty, it must return an error")
+	}
+}
+
+func TestCrossoverRate(t *testing.T) {
+	values1 := []float64{-10000, 10000, 1000, 1000}
+	values2 := []float64{-10000, 1000, 1000, 12000}
+	got, _ := CrossoverRate(values1, values2, 0.1)
+	if math.Abs(got-0.105541) > Precision {
+		t.Errorf("CrossoverRate(%v, %v, %f) = %f", values1, values2, 0.1, got)
+	}
+	if npv1, npv2 := projectNetPresentValue(got, values1), projectNetPresentValue(got, values2); math.Abs(npv1-npv2) > Precision {
+		t.Errorf("At the crossover rate both NPVs should be equal, got %f and %f", npv1, npv2)
+	}
+
+	if _, err := CrossoverRate(values1, values1, 0.1); err == nil {
+		t.Error("If the NPV profiles don't cross, it must return an error")
+	}
+
+	if _, err := CrossoverRate(values1, values2, -1); err == nil || err.Error() == "the projects' NPV profiles don't cross" {
+		t.Errorf("If the rate doesn't converge, it must return the solver's error, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// EmbeddedOption is the right to redeem a bond on Date at Price (clean, per 100 face value). The accrued interest is paid on top of it.
+type EmbeddedOption struct {
+	Date  time.Time
+	Price float64
+}
+
+// CallableBond is a fixed rate bond that the issuer can redeem early on the dates of the Calls schedule, and that the holder can sell back to the issuer
+// on the dates of the Puts schedule. Either schedule can be empty. Options are exercised only on the given dates (Bermudan style).
+type CallableBond struct {
+	FixedRateBond
+	Calls []EmbeddedOption
+	Puts  []EmbeddedOption
+}
+
+// Price returns the clean price of the bond at settlement under the given short rate model, optimally exercising the options.
+// The model is fitted to its Curve, whose reference date is taken to be settlement.
+// The future cash flows are discounted with spread added to the model's short rates, so a zero spread gives the bond's fair value.
+func (b CallableBond) Price(settlement time.Time, model HullWhite, spread float64) (float64, error) {
+	lattice, err := b.lattice(settlement, model)
+	if err != nil {
+		return 0, err
+	}
+	return lattice.price(spread), nil
+}
+
+// OptionAdjustedSpread returns the spread that must be added to the model's short rates for the bond's value to equal the given clean price.
+// The model is fitted to its Curve, whose reference date is taken to be settlement.
+// Since the options are valued by the model, the spread only compensates for the remaining risks, such as credit and liquidity.
+func (b CallableBond) OptionAdjustedSpread(settlement time.Time, model HullWhite, price float64) (float64, error) {
+	lattice, err := b.lattice(settlement, model)
+	if err != nil {
+		return 0, err
+	}
+	function := func(spread float64) float64 {
+		return lattice.price(spread) - price
+	}
+	x, y, err := bracket(function, -0.01, 0.01)
+	if err != nil {
+		return 0, err
+	}
+	return brent(function, x, y)
+}
+
+// YieldToCall returns the yield of the bond at settlement for the given clean price, assuming it's redeemed at the first call after settlement.
+func (b CallableBond) YieldToCall(settlement time.Time, price float64) (float64, error) {
+	calls := b.callsAfter(settlement)
+	if len(calls) == 0 {
+		return 0, errors.New("the bond has no calls after settlement")
+	}
+	first := calls[0]
+	for _, call := range calls {
+		if call.Date.Before(first.Date) {
+			first = call
+		}
+	}
+	return b.yieldTo(settlement, price, first.Date, first.Price)
+}
+
+// YieldToWorst returns the lowest of the yield to maturity and the yields to each call after settlement, for the given clean price.
+func (b CallableBond) YieldToWorst(settlement time.Time, price float64) (float64, error) {
+	worst, err := b.Yield(settlement, price)
+	if err != nil {
+		return 0, err
+	}
+	for _, call := range b.callsAfter(settlement) {
+		yield, err := b.yieldTo(settlement, price, call.Date, call.Price)
+		if err != nil {
+			return 0, err
+		}
+		worst = math.Min(worst, yield)
+	}
+	return worst, nil
+}
+
+func (b CallableBond) callsAfter(settlement time.Time) []EmbeddedOption {
+	calls := make([]EmbeddedOption, 0)
+	for _, call := range b.Calls {
+		if civilDate(call.Date).After(civilDate(settlement)) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// callableLattice holds the short rate tree of a callable bond, along with the bond's cash flows and exercise prices at each step
+type callableLattice struct {
+	tree *trinomialTree
+	// flows are the coupons paid at each step
+	flows []float64
+	// redemption is the amount repaid at the last step
+	redemption float64
+	// calls and puts are the exercise prices (including the accrued interest) at each step, infinite when the option can't be exercised
+	calls []float64
+	puts  []float64
+	// accrued is the accrued interest at settlement
+	accrued float64
+}
+
+// price returns the clean price of the bond, discounting with spread added to the short rates
+func (l *callableLattice) price(spread float64) float64 {
+	n := len(l.tree.times) - 1
+	values := make([]float64, 2*l.tree.width[n]+1)
+	for j := range values {
+		values[j] = l.redemption
+	}
+	for i := n; ; i-- {
+		for j := range values {
+			values[j] = math.Max(math.Min(values[j], l.calls[i]), l.puts[i]) + l.flows[i]
+		}
+		if i == 0 {
+			break
+		}
+		values = l.tree.rollback(values, i-1, spread)
+	}
+	return values[0] - l.accrued
+}
+
+func (b CallableBond) lattice(settlement time.Time, model HullWhite) (*callableLattice, error) {
+	dates, err := b.couponDates(settlement)
+	if err != nil {
+		return nil, err
+	}
+	settlement = civilDate(settlement)
+	options := append(append([]EmbeddedOption{}, b.Calls...), b.Puts...)
+	times := make([]float64, 0, len(dates)-1+len(options))
+	for _, date := range dates[1:] {
+		times = append(times, curveTime(settlement, date))
+	}
+	for _, option := range options {
+		date := civilDate(option.Date)
+		if date.After(dates[len(dates)-1]) {
+			return nil, errors.New("options can't be exercised after maturity")
+		}
+		if date.After(settlement) {
+			times = append(times, curveTime(settlement, date))
+		}
+	}
+	grid, steps, err := treeTimes(times, model.StepsPerYear)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := model.tree(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	lattice := &callableLattice{
+		tree:       tree,
+		flows:      make([]float64, len(grid)),
+		redemption: b.Redemption,
+		calls:      make([]float64, len(grid)),
+		puts:       make([]float64, len(grid)),
+		accrued:    b.accrued(dates[0], dates[1], settlement),
+	}
+	for i := range grid {
+		lattice.calls[i], lattice.puts[i] = math.Inf(1), math.Inf(-1)
+	}
+	for i := range dates[1:] {
+		lattice.flows[steps[i]] += 100 * b.Coupon / float64(b.Frequency)
+	}
+	for i, option := range options {
+		date := civilDate(option.Date)
+		if !date.After(settlement) {
+			continue
+		}
+		step := sort.SearchFloat64s(grid, curveTime(settlement, date))
+		price := option.Price + b.accruedAt(dates, date)
+		if i < len(b.Calls) {
+			lattice.calls[step] = math.Min(lattice.calls[step], price)
+		} else {
+			lattice.puts[step] = math.Max(lattice.puts[step], price)
+		}
+	}
+	return lattice, nil
+}
+
+// accruedAt returns the interest accrued at date, which must fall after the first of the coupon dates as returned by couponDates
+func (b CallableBond) accruedAt(dates []time.Time, date time.Time) float64 {
+	for i := 1; i < len(dates); i++ {
+		if date.Before(dates[i]) {
+			return b.accrued(dates[i-1], dates[i], date)
+		}
+	}
+	return 0
+}
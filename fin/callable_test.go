@@ -0,0 +1,125 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	callableSettlement = date(2020, time.January, 15)
+	callableBond       = FixedRateBond{Maturity: date(2030, time.January, 15), Coupon: 0.06, Frequency: 2, Basis: CountActualActual, Redemption: 100}
+)
+
+// curvePrice returns the clean price of bond discounting its cash flows with curve
+func curvePrice(bond FixedRateBond, settlement time.Time, curve DiscountCurve, until time.Time, redemption float64) float64 {
+	dates, amounts, _ := bond.CashFlows(settlement)
+	accrued, _ := bond.AccruedInterest(settlement)
+	price := 0.0
+	for i, date := range dates {
+		if date.After(until) {
+			break
+		}
+		amount := 100 * bond.Coupon / float64(bond.Frequency)
+		if date.Equal(until) {
+			amount += redemption
+		} else if i == len(dates)-1 {
+			amount = amounts[i]
+		}
+		price += amount * curve.DiscountFactor(curveTime(settlement, date))
+	}
+	return price - accrued
+}
+
+func TestCallableBondPrice(t *testing.T) {
+	curve := FlatCurve{Rate: 0.05}
+	model := HullWhite{Curve: curve, MeanReversion: 0.03, Volatility: 0.01, StepsPerYear: 12}
+	call := EmbeddedOption{Date: date(2025, time.January, 15), Price: 100}
+	straight := curvePrice(callableBond, callableSettlement, curve, callableBond.Maturity, 100)
+
+	// without options, the tree reprices the bond off the curve
+	if got, err := (CallableBond{FixedRateBond: callableBond}).Price(callableSettlement, model, 0); err != nil || math.Abs(straight-got) > Precision {
+		t.Errorf("CallableBond.Price() without options = %f, %v, want %f", got, err, straight)
+	}
+	// an out of the money call is worthless
+	if got, err := (CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{{Date: call.Date, Price: 1000}}}).Price(callableSettlement, model, 0); err != nil || math.Abs(straight-got) > Precision {
+		t.Errorf("CallableBond.Price() with an out of the money call = %f, %v, want %f", got, err, straight)
+	}
+	callable, err := CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{call}}.Price(callableSettlement, model, 0)
+	if err != nil || callable >= straight {
+		t.Errorf("CallableBond.Price() with a call = %f, %v, should be lower than %f", callable, err, straight)
+	}
+	putable, err := CallableBond{FixedRateBond: callableBond, Puts: []EmbeddedOption{call}}.Price(callableSettlement, model, 0)
+	if err != nil || putable <= straight {
+		t.Errorf("CallableBond.Price() with a put = %f, %v, should be higher than %f", putable, err, straight)
+	}
+	// the call is worth more with a higher volatility
+	model.Volatility = 0.02
+	if got, err := (CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{call}}).Price(callableSettlement, model, 0); err != nil || got >= callable {
+		t.Errorf("CallableBond.Price() with a higher volatility = %f, %v, should be lower than %f", got, err, callable)
+	}
+
+	// without volatility, the bond is called if that's cheaper for the issuer
+	model.Volatility = 1e-8
+	called := curvePrice(callableBond, callableSettlement, curve, call.Date, call.Price)
+	if got, err := (CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{call}}).Price(callableSettlement, model, 0); err != nil || math.Abs(math.Min(straight, called)-got) > Precision {
+		t.Errorf("CallableBond.Price() without volatility = %f, %v, want %f", got, err, math.Min(straight, called))
+	}
+
+	if _, err := (CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{{Date: date(2031, time.January, 15), Price: 100}}}).Price(callableSettlement, model, 0); err == nil {
+		t.Error("A call after maturity should return an error")
+	}
+}
+
+func TestCallableBondOptionAdjustedSpread(t *testing.T) {
+	model := HullWhite{Curve: FlatCurve{Rate: 0.05}, MeanReversion: 0.03, Volatility: 0.01, StepsPerYear: 12}
+	bond := CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{{Date: date(2023, time.January, 15), Price: 102}, {Date: date(2025, time.January, 15), Price: 100}}}
+	price, err := bond.Price(callableSettlement, model, 0.0075)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := bond.OptionAdjustedSpread(callableSettlement, model, price); err != nil || math.Abs(0.0075-got) > Precision {
+		t.Errorf("CallableBond.OptionAdjustedSpread(%v, %f) = %f, %v", callableSettlement, price, got, err)
+	}
+}
+
+func TestCallableBondYieldToCall(t *testing.T) {
+	bond := CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{{Date: date(2027, time.January, 15), Price: 100}, {Date: date(2025, time.January, 15), Price: 102}}}
+	toCall := FixedRateBond{Maturity: date(2025, time.January, 15), Coupon: 0.06, Frequency: 2, Basis: CountActualActual, Redemption: 102}
+	for _, price := range []float64{95, 100, 110} {
+		want, _ := toCall.Yield(callableSettlement, price)
+		if got, err := bond.YieldToCall(callableSettlement, price); err != nil || math.Abs(want-got) > Precision {
+			t.Errorf("CallableBond.YieldToCall(%v, %f) = %f, %v, want %f", callableSettlement, price, got, err, want)
+		}
+	}
+
+	if _, err := (CallableBond{FixedRateBond: callableBond}).YieldToCall(callableSettlement, 100); err == nil {
+		t.Error("A bond without calls should return an error")
+	}
+}
+
+func TestCallableBondYieldToWorst(t *testing.T) {
+	bond := CallableBond{FixedRateBond: callableBond, Calls: []EmbeddedOption{{Date: date(2025, time.January, 15), Price: 102}, {Date: date(2027, time.April, 1), Price: 100}}}
+	for _, price := range []float64{95, 100, 110} {
+		want, _ := bond.Yield(callableSettlement, price)
+		for _, call := range bond.Calls {
+			yield, _ := bond.yieldTo(callableSettlement, price, call.Date, call.Price)
+			want = math.Min(want, yield)
+		}
+		if got, err := bond.YieldToWorst(callableSettlement, price); err != nil || math.Abs(want-got) > Precision {
+			t.Errorf("CallableBond.YieldToWorst(%v, %f) = %f, %v, want %f", callableSettlement, price, got, err, want)
+		}
+	}
+
+	// at a premium, the worst yield is to the first call, and at a discount it's the yield to maturity
+	ytw, _ := bond.YieldToWorst(callableSettlement, 110)
+	ytc, _ := bond.YieldToCall(callableSettlement, 110)
+	if math.Abs(ytw-ytc) > Precision {
+		t.Errorf("CallableBond.YieldToWorst(%v, %f) = %f, want %f", callableSettlement, 110.0, ytw, ytc)
+	}
+	ytw, _ = bond.YieldToWorst(callableSettlement, 95)
+	ytm, _ := bond.Yield(callableSettlement, 95)
+	if math.Abs(ytw-ytm) > Precision {
+		t.Errorf("CallableBond.YieldToWorst(%v, %f) = %f, want %f", callableSettlement, 95.0, ytw, ytm)
+	}
+}
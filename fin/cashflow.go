@@ -73,28 +73,46 @@ func ModifiedInternalRateOfReturn(values []float64, financeRate float64, reinves
 	return math.Pow(-NetPresentValue(reinvestRate, positiveFlows)*math.Pow(1+reinvestRate, float64(nper))/NetPresentValue(financeRate, negativeFlows)/(1+financeRate), 1/float64(nper-1)) - 1, nil
 }
 
-// ScheduledNetPresentValue returns the Net Present Value of a scheduled cash flow series given a discount rate
+// ScheduledNetPresentValue returns the Net Present Value of a scheduled cash flow series given a discount rate.
+// Time between dates is measured in whole days on an actual/365 basis.
 //
 // Excel equivalent: XNPV
 func ScheduledNetPresentValue(rate float64, values []float64, dates []time.Time) (float64, error) {
+	return ScheduledNetPresentValueBasis(rate, values, dates, CountActual365)
+}
+
+// ScheduledNetPresentValueBasis returns the Net Present Value of a scheduled cash flow series given a discount rate,
+// measuring the time between dates with the given daycount basis (see the Count* constants).
+func ScheduledNetPresentValueBasis(rate float64, values []float64, dates []time.Time, basis int) (float64, error) {
 	if len(values) != len(dates) {
 		return 0, errors.New("values and dates must have the same length")
 	}
+	if !isValidBasis(basis) {
+		return 0, errors.New("invalid day count basis")
+	}
 
 	xnpv := 0.0
 	nper := len(values)
 	for i := 1; i <= nper; i++ {
-		exp := dates[i-1].Sub(dates[0]).Hours() / 24.0 / 365.0
+		exp := yearFraction(dates[0], dates[i-1], basis)
 		xnpv += values[i-1] / math.Pow(1+rate, exp)
 	}
 	return xnpv, nil
 }
 
 // ScheduledInternalRateOfReturn returns the internal rate of return of a scheduled cash flow series.
+// Time between dates is measured in whole days on an actual/365 basis.
 // Guess is a guess for the rate, used as a starting point for the iterative algorithm.
 //
 // Excel equivalent: XIRR
 func ScheduledInternalRateOfReturn(values []float64, dates []time.Time, guess float64) (float64, error) {
+	return ScheduledInternalRateOfReturnBasis(values, dates, CountActual365, guess)
+}
+
+// ScheduledInternalRateOfReturnBasis returns the internal rate of return of a scheduled cash flow series,
+// measuring the time between dates with the given daycount basis (see the Count* constants).
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+func ScheduledInternalRateOfReturnBasis(values []float64, dates []time.Time, basis int, guess float64) (float64, error) {
 	min, max := minMaxSlice(values)
 	if min*max >= 0 {
 		return 0, errors.New("the cash flow must contain at least one positive value and one negative value")
@@ -102,19 +120,77 @@ func ScheduledInternalRateOfReturn(values []float64, dates []time.Time, guess fl
 	if len(values) != len(dates) {
 		return 0, errors.New("values and dates must have the same length")
 	}
+	if !isValidBasis(basis) {
+		return 0, errors.New("invalid day count basis")
+	}
 
 	function := func(rate float64) float64 {
-		r, _ := ScheduledNetPresentValue(rate, values, dates)
+		r, _ := ScheduledNetPresentValueBasis(rate, values, dates, basis)
 		return r
 	}
 	derivative := func(rate float64) float64 {
-		r, _ := dScheduledNetPresentValue(rate, values, dates)
+		r, _ := dScheduledNetPresentValue(rate, values, dates, basis)
 		return r
 	}
 	return newton(guess, function, derivative, 0)
 }
 
-func dScheduledNetPresentValue(rate float64, values []float64, dates []time.Time) (float64, error) {
+// ScheduledModifiedInternalRateOfReturn returns the internal rate of return of a scheduled cash flow series, considering both financial and reinvestment rates.
+// Positive values are compounded at reinvestRate up to the last date and negative values are discounted at financeRate to the first date.
+// Time between dates is measured in whole days on an actual/365 basis.
+//
+// financeRate is the rate on the money used in the cash flow.
+//
+// reinvestRate is the rate received when reinvested
+func ScheduledModifiedInternalRateOfReturn(values []float64, dates []time.Time, financeRate float64, reinvestRate float64) (float64, error) {
+	return ScheduledModifiedInternalRateOfReturnBasis(values, dates, financeRate, reinvestRate, CountActual365)
+}
+
+// ScheduledModifiedInternalRateOfReturnBasis returns the modified internal rate of return of a scheduled cash flow series,
+// measuring the time between dates with the given daycount basis (see the Count* constants).
+func ScheduledModifiedInternalRateOfReturnBasis(values []float64, dates []time.Time, financeRate float64, reinvestRate float64, basis int) (float64, error) {
+	if len(values) < 2 {
+		return 0, errors.New("the cash flow must contain at least two values")
+	}
+	if len(values) != len(dates) {
+		return 0, errors.New("values and dates must have the same length")
+	}
+	min, max := minMaxSlice(values)
+	if min*max >= 0 {
+		return 0, errors.New("the cash flow must contain at least one positive value and one negative value")
+	}
+	if !isValidBasis(basis) {
+		return 0, errors.New("invalid day count basis")
+	}
+
+	last := dates[0]
+	for _, date := range dates {
+		if date.Before(dates[0]) {
+			return 0, errors.New("no date can precede the first date")
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	years := yearFraction(dates[0], last, basis)
+	if years <= 0 {
+		return 0, errors.New("the cash flow must span more than one day")
+	}
+
+	fvPositive, pvNegative := 0.0, 0.0
+	for i, value := range values {
+		if value >= 0 {
+			exp := yearFraction(dates[i], last, basis)
+			fvPositive += value * math.Pow(1+reinvestRate, exp)
+		} else {
+			exp := yearFraction(dates[0], dates[i], basis)
+			pvNegative += value / math.Pow(1+financeRate, exp)
+		}
+	}
+	return math.Pow(-fvPositive/pvNegative, 1/years) - 1, nil
+}
+
+func dScheduledNetPresentValue(rate float64, values []float64, dates []time.Time, basis int) (float64, error) {
 	if len(values) != len(dates) {
 		return 0, errors.New("values and dates must have the same length")
 	}
@@ -122,7 +198,7 @@ func dScheduledNetPresentValue(rate float64, values []float64, dates []time.Time
 	dxnpv := 0.0
 	nper := len(values)
 	for i := 1; i <= nper; i++ {
-		exp := dates[i-1].Sub(dates[0]).Hours() / 24.0 / 365.0
+		exp := yearFraction(dates[0], dates[i-1], basis)
 		dxnpv -= values[i-1] * exp / math.Pow(1+rate, exp+1)
 	}
 	return dxnpv, nil
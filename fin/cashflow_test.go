@@ -178,3 +178,125 @@ func TestScheduledInternalRateOfReturn(t *testing.T) {
 		t.Error("If values and dates have different lengths, it must return an error")
 	}
 }
+
+func TestScheduledModifiedInternalRateOfReturn(t *testing.T) {
+	start := time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearly := func(n int) []time.Time {
+		dates := make([]time.Time, n)
+		for i := range dates {
+			dates[i] = start.AddDate(0, 0, 365*i)
+		}
+		return dates
+	}
+
+	var tests = []struct {
+		values       []float64
+		dates        []time.Time
+		financeRate  float64
+		reinvestRate float64
+		want         float64
+	}{
+		// with 365 days between flows it must match ModifiedInternalRateOfReturn
+		{[]float64{-120000, 39000, 30000, 21000, 37000, 46000}, yearly(6), 0.10, 0.12, 0.126094},
+		{[]float64{-120000, 39000, 30000, 21000}, yearly(4), 0.10, 0.12, -0.048044},
+		{
+			[]float64{-10000, 2750, -4250, 3250, 12750},
+			[]time.Time{
+				time.Date(2008, time.Month(1), 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2008, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2008, time.Month(10), 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2009, time.Month(2), 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2009, time.Month(4), 1, 0, 0, 0, 0, time.UTC),
+			},
+			0.09,
+			0.10,
+			0.284827,
+		},
+	}
+
+	for _, test := range tests {
+		if got, _ := ScheduledModifiedInternalRateOfReturn(test.values, test.dates, test.financeRate, test.reinvestRate); math.Abs(test.want-got) > Precision {
+			t.Errorf("ScheduledModifiedInternalRateOfReturn(%v, %v, %f, %f) = %f", test.values, test.dates, test.financeRate, test.reinvestRate, got)
+		}
+	}
+
+	if _, err := ScheduledModifiedInternalRateOfReturn([]float64{10000, 2750}, yearly(2), 0.1, 0.1); err == nil {
+		t.Error("If the cash flow doesn't contain at least one positive value and one negative value, it must return an error")
+	}
+
+	if _, err := ScheduledModifiedInternalRateOfReturn([]float64{-10000, 2750}, yearly(1), 0.1, 0.1); err == nil {
+		t.Error("If values and dates have different lengths, it must return an error")
+	}
+
+	if _, err := ScheduledModifiedInternalRateOfReturn(nil, nil, 0.1, 0.1); err == nil {
+		t.Error("An empty cash flow must return an error")
+	}
+
+	if _, err := ScheduledModifiedInternalRateOfReturnBasis([]float64{-10000, 2750}, nil, 0.1, 0.1, CountActual365); err == nil {
+		t.Error("If there are no dates, it must return an error")
+	}
+
+	if _, err := ScheduledModifiedInternalRateOfReturn([]float64{-10000, 2750}, []time.Time{start, start.AddDate(0, 0, -1)}, 0.1, 0.1); err == nil {
+		t.Error("If a date precedes the first date, it must return an error")
+	}
+}
+
+func TestScheduledNetPresentValueBasis(t *testing.T) {
+	values := []float64{-10000, 2750, 4250, 3250, 2750}
+	dates := []time.Time{
+		time.Date(2008, time.Month(1), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(10), 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(2), 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(4), 1, 0, 0, 0, 0, time.UTC),
+	}
+	var tests = []struct {
+		basis int
+		want  float64
+	}{
+		{CountActual365, 2086.647602},
+		{CountActual360, 2074.522785},
+		{CountNasd, 2086.793074},
+	}
+
+	for _, test := range tests {
+		if got, _ := ScheduledNetPresentValueBasis(0.09, values, dates, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("ScheduledNetPresentValueBasis(%f, %v, %v, %d) = %f", 0.09, values, dates, test.basis, got)
+		}
+	}
+
+	// local midnights across a DST transition must count as whole days
+	est, edt := time.FixedZone("EST", -5*3600), time.FixedZone("EDT", -4*3600)
+	localDates := []time.Time{
+		time.Date(2008, time.Month(1), 1, 0, 0, 0, 0, est),
+		time.Date(2008, time.Month(3), 1, 0, 0, 0, 0, est),
+		time.Date(2008, time.Month(10), 30, 0, 0, 0, 0, edt),
+		time.Date(2009, time.Month(2), 15, 0, 0, 0, 0, est),
+		time.Date(2009, time.Month(4), 1, 0, 0, 0, 0, edt),
+	}
+	if got, _ := ScheduledNetPresentValue(0.09, values, localDates); math.Abs(2086.647602-got) > Precision {
+		t.Errorf("ScheduledNetPresentValue(%f, %v, %v) = %f", 0.09, values, localDates, got)
+	}
+
+	if _, err := ScheduledNetPresentValueBasis(0.09, values, dates, 7); err == nil {
+		t.Error("An invalid basis should return an error")
+	}
+}
+
+func TestScheduledInternalRateOfReturnBasis(t *testing.T) {
+	values := []float64{-10000, 2750, 4250, 3250, 2750}
+	dates := []time.Time{
+		time.Date(2008, time.Month(1), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(10), 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(2), 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(4), 1, 0, 0, 0, 0, time.UTC),
+	}
+	if got, _ := ScheduledInternalRateOfReturnBasis(values, dates, CountActual360, 0.1); math.Abs(0.367407-got) > Precision {
+		t.Errorf("ScheduledInternalRateOfReturnBasis(%v, %v, %d, %f) = %f", values, dates, CountActual360, 0.1, got)
+	}
+
+	if _, err := ScheduledInternalRateOfReturnBasis(values, dates, 7, 0.1); err == nil {
+		t.Error("An invalid basis should return an error")
+	}
+}
@@ -0,0 +1,140 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// DiscountCurve is a term structure of interest rates, giving the discount factor applicable to a cash flow as a function of its time.
+//
+// Times are expressed in years from the curve's reference date. The functions that take dates measure them from the first date (or the
+// valuation date) on an actual/365 basis.
+type DiscountCurve interface {
+	// DiscountFactor returns the present value of one unit paid at time t.
+	DiscountFactor(t float64) float64
+}
+
+// FlatCurve is a term structure with the same annually compounded rate for every maturity.
+// Discounting with it is equivalent to discounting with a single rate.
+type FlatCurve struct {
+	Rate float64
+}
+
+// DiscountFactor returns the present value of one unit paid at time t.
+func (c FlatCurve) DiscountFactor(t float64) float64 {
+	return math.Pow(1+c.Rate, -t)
+}
+
+// PiecewiseConstantCurve is a term structure whose instantaneous forward rate (continuously compounded) is constant between consecutive times.
+type PiecewiseConstantCurve struct {
+	times []float64
+	rates []float64
+}
+
+// NewPiecewiseConstantCurve returns a term structure where rates[i] is the forward rate between times[i-1] (zero for the first one) and times[i].
+// The last rate is kept constant beyond the last time.
+func NewPiecewiseConstantCurve(times []float64, rates []float64) (*PiecewiseConstantCurve, error) {
+	if err := validateCurvePoints(times, rates); err != nil {
+		return nil, err
+	}
+	return &PiecewiseConstantCurve{
+		times: append([]float64{}, times...),
+		rates: append([]float64{}, rates...),
+	}, nil
+}
+
+// DiscountFactor returns the present value of one unit paid at time t.
+func (c *PiecewiseConstantCurve) DiscountFactor(t float64) float64 {
+	integral, from := 0.0, 0.0
+	for i, to := range c.times {
+		if t <= to || i == len(c.times)-1 {
+			return math.Exp(-integral - c.rates[i]*(t-from))
+		}
+		integral += c.rates[i] * (to - from)
+		from = to
+	}
+	return 1
+}
+
+// InterpolatedCurve is a term structure built from continuously compounded zero rates at given times,
+// linearly interpolated in between and kept flat before the first time and beyond the last one.
+type InterpolatedCurve struct {
+	times     []float64
+	zeroRates []float64
+}
+
+// NewInterpolatedCurve returns a term structure interpolating the zero rates (continuously compounded) given at each one of times.
+func NewInterpolatedCurve(times []float64, zeroRates []float64) (*InterpolatedCurve, error) {
+	if err := validateCurvePoints(times, zeroRates); err != nil {
+		return nil, err
+	}
+	return &InterpolatedCurve{
+		times:     append([]float64{}, times...),
+		zeroRates: append([]float64{}, zeroRates...),
+	}, nil
+}
+
+// DiscountFactor returns the present value of one unit paid at time t.
+func (c *InterpolatedCurve) DiscountFactor(t float64) float64 {
+	return math.Exp(-linearInterpolation(c.times, c.zeroRates, t) * t)
+}
+
+// NetPresentValueCurve returns the Net Present Value of a cash flow series, discounting each value at the curve's rate for its maturity.
+// As in NetPresentValue, the values occur at the end of each period, each period being one year.
+func NetPresentValueCurve(curve DiscountCurve, values []float64) float64 {
+	npv := 0.0
+	for i, value := range values {
+		npv += value * curve.DiscountFactor(float64(i+1))
+	}
+	return npv
+}
+
+// ScheduledNetPresentValueCurve returns the Net Present Value of a scheduled cash flow series, discounting each value at the curve's rate for its maturity.
+// As in ScheduledNetPresentValue, the values are discounted to the first date, which is taken as the curve's reference date.
+func ScheduledNetPresentValueCurve(curve DiscountCurve, values []float64, dates []time.Time) (float64, error) {
+	if len(values) != len(dates) {
+		return 0, errors.New("values and dates must have the same length")
+	}
+
+	npv := 0.0
+	for i, value := range values {
+		npv += value * curve.DiscountFactor(curveTime(dates[0], dates[i]))
+	}
+	return npv, nil
+}
+
+// curveTime returns the time in years of date since the curve's reference date
+func curveTime(reference time.Time, date time.Time) float64 {
+	return yearFraction(reference, date, CountActual365)
+}
+
+// linearInterpolation returns the value at x of the linear interpolation of the points (xs, ys), flat outside of them
+func linearInterpolation(xs []float64, ys []float64, x float64) float64 {
+	if x <= xs[0] {
+		return ys[0]
+	}
+	for i := 1; i < len(xs); i++ {
+		if x <= xs[i] {
+			return ys[i-1] + (ys[i]-ys[i-1])*(x-xs[i-1])/(xs[i]-xs[i-1])
+		}
+	}
+	return ys[len(ys)-1]
+}
+
+func validateCurvePoints(times []float64, rates []float64) error {
+	if len(times) != len(rates) {
+		return errors.New("times and rates must have the same length")
+	}
+	if len(times) == 0 {
+		return errors.New("at least one point is required")
+	}
+	previous := 0.0
+	for _, t := range times {
+		if t <= previous {
+			return errors.New("times must be positive and in ascending order")
+		}
+		previous = t
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPiecewiseConstantCurve(t *testing.T) {
+	curve, err := NewPiecewiseConstantCurve([]float64{1, 2}, []float64{0.05, 0.06})
+	if err != nil {
+		t.Fatalf("NewPiecewiseConstantCurve returned error %v", err)
+	}
+	var tests = []struct {
+		t    float64
+		want float64
+	}{
+		{0, 1},
+		{0.5, 0.975310},
+		{1.5, 0.923116},
+		{3, 0.843665},
+	}
+
+	for _, test := range tests {
+		if got := curve.DiscountFactor(test.t); math.Abs(test.want-got) > Precision {
+			t.Errorf("PiecewiseConstantCurve.DiscountFactor(%f) = %f", test.t, got)
+		}
+	}
+
+	if _, err := NewPiecewiseConstantCurve([]float64{2, 1}, []float64{0.05, 0.06}); err == nil {
+		t.Error("If times aren't in ascending order, it must return an error")
+	}
+	if _, err := NewPiecewiseConstantCurve([]float64{1, 2}, []float64{0.05}); err == nil {
+		t.Error("If times and rates have different lengths, it must return an error")
+	}
+}
+
+func TestInterpolatedCurve(t *testing.T) {
+	curve, err := NewInterpolatedCurve([]float64{1, 3}, []float64{0.04, 0.06})
+	if err != nil {
+		t.Fatalf("NewInterpolatedCurve returned error %v", err)
+	}
+	var tests = []struct {
+		t    float64
+		want float64
+	}{
+		{0, 1},
+		{0.5, 0.980199},
+		{2, 0.904837},
+		{4, 0.786628},
+	}
+
+	for _, test := range tests {
+		if got := curve.DiscountFactor(test.t); math.Abs(test.want-got) > Precision {
+			t.Errorf("InterpolatedCurve.DiscountFactor(%f) = %f", test.t, got)
+		}
+	}
+
+	if _, err := NewInterpolatedCurve([]float64{0, 1}, []float64{0.04, 0.06}); err == nil {
+		t.Error("If a time isn't positive, it must return an error")
+	}
+}
+
+func TestNetPresentValueCurve(t *testing.T) {
+	values := []float64{-10000, 3000, 4200, 6800}
+	if got := NetPresentValueCurve(FlatCurve{0.1}, values); math.Abs(got-NetPresentValue(0.1, values)) > Precision {
+		t.Errorf("NetPresentValueCurve(FlatCurve{0.1}, %v) = %f", values, got)
+	}
+
+	curve, _ := NewPiecewiseConstantCurve([]float64{1, 2}, []float64{0.05, 0.06})
+	if got := NetPresentValueCurve(curve, []float64{100, 100, 100}); math.Abs(got-269.072838) > Precision {
+		t.Errorf("NetPresentValueCurve(%v, %v) = %f", curve, []float64{100, 100, 100}, got)
+	}
+}
+
+func TestScheduledNetPresentValueCurve(t *testing.T) {
+	values := []float64{-10000, 2750, 4250, 3250, 2750}
+	dates := []time.Time{
+		time.Date(2008, time.Month(1), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(10), 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(2), 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(4), 1, 0, 0, 0, 0, time.UTC),
+	}
+	if got, _ := ScheduledNetPresentValueCurve(FlatCurve{0.09}, values, dates); math.Abs(got-2086.647602) > Precision {
+		t.Errorf("ScheduledNetPresentValueCurve(FlatCurve{0.09}, %v, %v) = %f", values, dates, got)
+	}
+
+	curve, _ := NewInterpolatedCurve([]float64{0.5, 1.5}, []float64{0.05, 0.07})
+	if got, _ := ScheduledNetPresentValueCurve(curve, values, dates); math.Abs(got-2347.033375) > Precision {
+		t.Errorf("ScheduledNetPresentValueCurve(%v, %v, %v) = %f", curve, values, dates, got)
+	}
+
+	if _, err := ScheduledNetPresentValueCurve(curve, values, dates[1:]); err == nil {
+		t.Error("If values and dates have different lengths, it must return an error")
+	}
+}
@@ -0,0 +1,157 @@
+package fin
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, the unscaled integer value times 10^-scale, for amounts that must reconcile to the cent.
+// The zero value is zero. Decimals are immutable: the operations return new values.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// NewDecimal returns the decimal unscaled × 10^-scale, so that NewDecimal(12345, 2) is 123.45.
+func NewDecimal(unscaled int64, scale int) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// NewDecimalFromFloat returns the shortest decimal that converts back to x, so that NewDecimalFromFloat(0.1) is exactly 0.1.
+func NewDecimalFromFloat(x float64) (Decimal, error) {
+	return ParseDecimal(strconv.FormatFloat(x, 'f', -1, 64))
+}
+
+// ParseDecimal returns the decimal represented by s, such as "-1234.50". The scale is the number of digits after the decimal point.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, errors.New("invalid decimal " + strconv.Quote(s))
+	}
+	scale := 0
+	if point := strings.IndexByte(digits, '.'); point >= 0 {
+		scale = len(digits) - point - 1
+		digits = digits[:point] + digits[point+1:]
+	}
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, errors.New("invalid decimal " + strconv.Quote(s))
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if strings.HasPrefix(s, "-") {
+		unscaled.Neg(unscaled)
+	}
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1 depending on whether d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.integer().Sign()
+}
+
+// Cmp returns -1, 0 or 1 depending on whether d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := align(d, other)
+	return a.Cmp(b)
+}
+
+// Add returns d + other, with the larger of both scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{unscaled: a.Add(a, b), scale: maxInt(d.scale, other.scale)}
+}
+
+// Sub returns d - other, with the larger of both scales.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{unscaled: a.Sub(a, b), scale: maxInt(d.scale, other.scale)}
+}
+
+// Mul returns d × other, with the sum of both scales.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.integer(), other.integer()), scale: d.scale + other.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.integer()), scale: d.scale}
+}
+
+// Quo returns d / other rounded to scale digits after the decimal point with the rounding mode (see the Round* constants).
+func (d Decimal) Quo(other Decimal, scale int, mode int) (Decimal, error) {
+	if err := validateRoundingMode(mode); err != nil {
+		return Decimal{}, err
+	}
+	if other.Sign() == 0 {
+		return Decimal{}, errors.New("division by zero")
+	}
+	return roundRat(new(big.Rat).Quo(d.rat(), other.rat()), scale, mode), nil
+}
+
+// Round returns d rounded to scale digits after the decimal point with the rounding mode (see the Round* constants).
+func (d Decimal) Round(scale int, mode int) (Decimal, error) {
+	if err := validateRoundingMode(mode); err != nil {
+		return Decimal{}, err
+	}
+	return roundRat(d.rat(), scale, mode), nil
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.rat().Float64()
+	return f
+}
+
+// String returns d with all the digits of its scale, such as "-1234.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.integer()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.scale)
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+}
+
+func (d Decimal) integer() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func (d Decimal) rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.integer())
+	if d.scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow10(d.scale)))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow10(-d.scale)))
+}
+
+// align returns the unscaled values of a and b (as new integers) at the larger of their scales
+func align(a Decimal, b Decimal) (*big.Int, *big.Int) {
+	scale := maxInt(a.scale, b.scale)
+	return new(big.Int).Mul(a.integer(), pow10(scale-a.scale)), new(big.Int).Mul(b.integer(), pow10(scale-b.scale))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,116 @@
+package fin
+
+import (
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	var tests = []struct {
+		s     string
+		want  string
+		scale int
+	}{
+		{"123.45", "123.45", 2},
+		{"-0.05", "-0.05", 2},
+		{"+7", "7", 0},
+		{"1000.000", "1000.000", 3},
+		{".5", "0.5", 1},
+	}
+
+	for _, test := range tests {
+		if got, err := ParseDecimal(test.s); err != nil || got.String() != test.want || got.Scale() != test.scale {
+			t.Errorf("ParseDecimal(%q) = %s (scale %d), %v", test.s, got, got.Scale(), err)
+		}
+	}
+
+	for _, s := range []string{"", "-", "1.2.3", "--1", "1e3", "12a"} {
+		if _, err := ParseDecimal(s); err == nil {
+			t.Errorf("ParseDecimal(%q) should return an error", s)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	var tests = []struct {
+		d     string
+		scale int
+		mode  int
+		want  string
+	}{
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"-2.345", 2, RoundHalfEven, "-2.34"},
+		{"2.3451", 2, RoundHalfEven, "2.35"},
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"2.344", 2, RoundHalfUp, "2.34"},
+		{"2.349", 2, RoundDown, "2.34"},
+		{"-2.349", 2, RoundDown, "-2.34"},
+		{"2.341", 2, RoundUp, "2.35"},
+		{"-2.341", 2, RoundUp, "-2.35"},
+		{"2.341", 2, RoundCeiling, "2.35"},
+		{"-2.349", 2, RoundCeiling, "-2.34"},
+		{"2.349", 2, RoundFloor, "2.34"},
+		{"-2.341", 2, RoundFloor, "-2.35"},
+		{"2.34", 2, RoundUp, "2.34"},
+		{"2.5", 0, RoundHalfEven, "2"},
+		{"1250", -2, RoundHalfEven, "1200"},
+		{"1250", -2, RoundHalfUp, "1300"},
+		{"7", 2, RoundHalfEven, "7.00"},
+	}
+
+	for _, test := range tests {
+		if got, err := decimal(test.d).Round(test.scale, test.mode); err != nil || got.String() != test.want {
+			t.Errorf("Decimal(%s).Round(%d, %d) = %s, %v, want %s", test.d, test.scale, test.mode, got, err, test.want)
+		}
+	}
+
+	if _, err := decimal("1.5").Round(0, 6); err == nil {
+		t.Error("An invalid rounding mode should return an error")
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, _ := ParseDecimal("10.25")
+	b, _ := ParseDecimal("-3.125")
+	var tests = []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"Add", a.Add(b), "7.125"},
+		{"Sub", a.Sub(b), "13.375"},
+		{"Mul", a.Mul(b), "-32.03125"},
+		{"Neg", b.Neg(), "3.125"},
+		{"NewDecimal", NewDecimal(-5, 3), "-0.005"},
+		{"zero value", Decimal{}.Add(a), "10.25"},
+	}
+
+	for _, test := range tests {
+		if got := test.got.String(); got != test.want {
+			t.Errorf("%s = %s, want %s", test.name, got, test.want)
+		}
+	}
+
+	if got, err := a.Quo(NewDecimal(3, 0), 4, RoundHalfEven); err != nil || got.String() != "3.4167" {
+		t.Errorf("Quo() = %s, %v", got, err)
+	}
+	if got, err := a.Quo(NewDecimal(3, 0), 4, RoundDown); err != nil || got.String() != "3.4166" {
+		t.Errorf("Quo() rounding down = %s, %v", got, err)
+	}
+	if _, err := a.Quo(Decimal{}, 2, RoundHalfEven); err == nil {
+		t.Error("A division by zero should return an error")
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(NewDecimal(102500, 4)) != 0 {
+		t.Error("Cmp() returned a wrong ordering")
+	}
+	if b.Sign() != -1 || (Decimal{}).Sign() != 0 {
+		t.Error("Sign() returned a wrong sign")
+	}
+	if got := b.Float64(); got != -3.125 {
+		t.Errorf("Float64() = %f", got)
+	}
+	if got, err := NewDecimalFromFloat(0.1); err != nil || got.String() != "0.1" {
+		t.Errorf("NewDecimalFromFloat(0.1) = %s, %v", got, err)
+	}
+}
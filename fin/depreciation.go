@@ -3,6 +3,7 @@ package fin
 import (
 	"errors"
 	"math"
+	"math/big"
 )
 
 // DepreciationFixedDeclining returns the depreciation of an asset using the fixed-declining balance method
@@ -15,8 +16,10 @@ func DepreciationFixedDeclining(cost float64, salvage float64, life int, period
 	if period < 1 {
 		return 0, errors.New("period must be greater or equal than one")
 	}
-	rate := 1 - math.Pow((salvage/cost), (1/float64(life)))
-	rate = round(rate, 3)
+	rate, err := Round(1-math.Pow((salvage/cost), (1/float64(life))), 3, RoundHalfUp)
+	if err != nil {
+		return 0, err
+	}
 	accDepreciation := 0.0
 	var depreciationPeriod float64
 	for i := 1; i <= period; i++ {
@@ -49,27 +52,93 @@ func DepreciationSYD(cost float64, salvage float64, life int, per int) float64 {
 	return ((cost - salvage) * float64(life-per+1) * 2 / float64(life) / float64(life+1))
 }
 
-func round(x float64, prec int) float64 {
-	if math.IsNaN(x) || math.IsInf(x, 0) {
-		return x
+// DepreciationScheduleStraightLine returns the straight-line depreciation of an asset for each of the life periods, rounded to scale digits
+// after the decimal point (see MinorUnits) with the rounding mode (see the Round* constants).
+// The last period absorbs the rounding residual, so that the depreciations add up exactly to cost - salvage.
+func DepreciationScheduleStraightLine(cost Decimal, salvage Decimal, life int, scale int, mode int) ([]Decimal, error) {
+	if cost.Sign() < 0 || life < 1 {
+		return nil, errors.New("cost and life must be absolute positive numbers")
 	}
-
-	sign := 1.0
-	if x < 0 {
-		sign = -1
-		x *= -1
+	if err := validateRoundingMode(mode); err != nil {
+		return nil, err
+	}
+	depreciable := cost.Sub(salvage).rat()
+	exact := make([]*big.Rat, life)
+	for i := range exact {
+		exact[i] = new(big.Rat).Quo(depreciable, new(big.Rat).SetInt64(int64(life)))
 	}
+	return roundSchedule(exact, scale, mode), nil
+}
 
-	var rounder float64
-	pow := math.Pow(10, float64(prec))
-	intermed := x * pow
-	_, frac := math.Modf(intermed)
+// DepreciationScheduleSYD returns the sum-of-years' digits depreciation of an asset for each of the life periods, rounded to scale digits
+// after the decimal point (see MinorUnits) with the rounding mode (see the Round* constants).
+// The last period absorbs the rounding residual, so that the depreciations add up exactly to cost - salvage.
+func DepreciationScheduleSYD(cost Decimal, salvage Decimal, life int, scale int, mode int) ([]Decimal, error) {
+	if cost.Sign() < 0 || life < 1 {
+		return nil, errors.New("cost and life must be absolute positive numbers")
+	}
+	if err := validateRoundingMode(mode); err != nil {
+		return nil, err
+	}
+	depreciable := cost.Sub(salvage).rat()
+	digits := new(big.Rat).SetInt64(int64(life * (life + 1) / 2))
+	exact := make([]*big.Rat, life)
+	for i := range exact {
+		exact[i] = new(big.Rat).Mul(depreciable, new(big.Rat).SetInt64(int64(life-i)))
+		exact[i].Quo(exact[i], digits)
+	}
+	return roundSchedule(exact, scale, mode), nil
+}
 
-	if frac >= 0.5 {
-		rounder = math.Ceil(intermed)
-	} else {
-		rounder = math.Floor(intermed)
+// DepreciationScheduleFixedDeclining returns the fixed-declining balance depreciation of an asset for each period, rounded to scale digits
+// after the decimal point (see MinorUnits) with the rounding mode (see the Round* constants), where month is the number of months in the first year.
+// There are life periods, plus a final partial one when month is less than 12.
+// The depreciation rate is rounded to three decimal places as in DepreciationFixedDeclining, and the rest of the computation is exact.
+// The last period absorbs the rounding residual, so that the depreciations add up exactly to the rounded total depreciation.
+func DepreciationScheduleFixedDeclining(cost Decimal, salvage Decimal, life int, month int, scale int, mode int) ([]Decimal, error) {
+	if cost.Sign() <= 0 || life < 1 {
+		return nil, errors.New("cost and life must be absolute positive numbers")
+	}
+	if month < 1 || month > 12 {
+		return nil, errors.New("month must be between 1 and 12")
 	}
+	if err := validateRoundingMode(mode); err != nil {
+		return nil, err
+	}
+	rate, err := NewDecimalFromFloat(1 - math.Pow(salvage.Float64()/cost.Float64(), 1/float64(life)))
+	if err != nil {
+		return nil, err
+	}
+	rate = roundRat(rate.rat(), 3, RoundHalfUp)
+	periods := life + 1
+	if month == 12 {
+		periods = life
+	}
+	book, r := cost.rat(), rate.rat()
+	exact := make([]*big.Rat, periods)
+	for i := range exact {
+		exact[i] = new(big.Rat).Mul(book, r)
+		if i == 0 {
+			exact[i].Mul(exact[i], big.NewRat(int64(month), 12))
+		} else if i == life {
+			exact[i].Mul(exact[i], big.NewRat(int64(12-month), 12))
+		}
+		book = new(big.Rat).Sub(book, exact[i])
+	}
+	return roundSchedule(exact, scale, mode), nil
+}
 
-	return rounder / pow * sign
+// roundSchedule rounds the amounts to scale digits after the decimal point with the rounding mode, except the last one, which is the rounded total less the other rounded amounts
+func roundSchedule(exact []*big.Rat, scale int, mode int) []Decimal {
+	rounded := make([]Decimal, len(exact))
+	total, sum := new(big.Rat), NewDecimal(0, scale)
+	for i, amount := range exact {
+		total.Add(total, amount)
+		if i < len(exact)-1 {
+			rounded[i] = roundRat(amount, scale, mode)
+			sum = sum.Add(rounded[i])
+		}
+	}
+	rounded[len(exact)-1] = roundRat(total, scale, mode).Sub(sum)
+	return rounded
 }
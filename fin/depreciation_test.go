@@ -65,3 +65,53 @@ func TestDepreciationSYD(t *testing.T) {
 		}
 	}
 }
+
+func TestDepreciationScheduleStraightLine(t *testing.T) {
+	got, err := DepreciationScheduleStraightLine(NewDecimal(1000, 0), Decimal{}, 3, 2, RoundHalfUp)
+	if err != nil || len(got) != 3 || got[0].String() != "333.33" || got[1].String() != "333.33" || got[2].String() != "333.34" {
+		t.Errorf("DepreciationScheduleStraightLine() = %v, %v", got, err)
+	}
+	if _, err := DepreciationScheduleStraightLine(NewDecimal(1000, 0), Decimal{}, 0, 2, RoundHalfUp); err == nil {
+		t.Error("A zero life should return an error")
+	}
+}
+
+func TestDepreciationScheduleSYD(t *testing.T) {
+	got, err := DepreciationScheduleSYD(NewDecimal(30000, 0), NewDecimal(7500, 0), 10, 2, RoundHalfUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"4090.91", "3681.82", "3272.73", "2863.64", "2454.55", "2045.45", "1636.36", "1227.27", "818.18", "409.09"}
+	total := Decimal{}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Errorf("DepreciationScheduleSYD() period %d = %s, want %s", i+1, got[i], want[i])
+		}
+		total = total.Add(got[i])
+	}
+	if total.String() != "22500.00" {
+		t.Errorf("DepreciationScheduleSYD() adds up to %s", total)
+	}
+}
+
+func TestDepreciationScheduleFixedDeclining(t *testing.T) {
+	got, err := DepreciationScheduleFixedDeclining(NewDecimal(1000000, 0), NewDecimal(100000, 0), 6, 7, 2, RoundHalfUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"186083.33", "259639.42", "176814.44", "120410.64", "81999.64", "55841.76", "15845.10"}
+	if len(got) != len(want) {
+		t.Fatalf("DepreciationScheduleFixedDeclining() = %v", got)
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Errorf("DepreciationScheduleFixedDeclining() period %d = %s, want %s", i+1, got[i], want[i])
+		}
+	}
+	if got, _ := DepreciationScheduleFixedDeclining(NewDecimal(1000000, 0), NewDecimal(100000, 0), 6, 12, 2, RoundHalfUp); len(got) != 6 {
+		t.Errorf("DepreciationScheduleFixedDeclining() with a full first year has %d periods", len(got))
+	}
+	if _, err := DepreciationScheduleFixedDeclining(NewDecimal(1000000, 0), NewDecimal(100000, 0), 6, 13, 2, RoundHalfUp); err == nil {
+		t.Error("An invalid month should return an error")
+	}
+}
@@ -0,0 +1,160 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// FixedRateBond is a bond paying an annual Coupon rate on 100 face value Frequency times per year until Maturity, when it repays Redemption (per 100 face value).
+//
+// Coupon dates roll backwards from Maturity, and the accrued interest is measured with the daycount basis (see the Count* constants) as in Excel's bond functions.
+// Prices are clean (excluding the accrued interest) per 100 face value, and yields are compounded Frequency times per year.
+type FixedRateBond struct {
+	Maturity   time.Time
+	Coupon     float64
+	Frequency  int
+	Basis      int
+	Redemption float64
+}
+
+// Price returns the clean price of the bond at settlement for the given yield.
+//
+// Excel equivalent: PRICE
+func (b FixedRateBond) Price(settlement time.Time, yield float64) (float64, error) {
+	return b.priceTo(settlement, yield, b.Maturity, b.Redemption)
+}
+
+// Yield returns the yield to maturity of the bond at settlement for the given clean price.
+//
+// Excel equivalent: YIELD
+func (b FixedRateBond) Yield(settlement time.Time, price float64) (float64, error) {
+	return b.yieldTo(settlement, price, b.Maturity, b.Redemption)
+}
+
+// AccruedInterest returns the interest accrued from the last coupon date up to settlement, per 100 face value.
+func (b FixedRateBond) AccruedInterest(settlement time.Time) (float64, error) {
+	dates, err := b.couponDates(settlement)
+	if err != nil {
+		return 0, err
+	}
+	return b.accrued(dates[0], dates[1], settlement), nil
+}
+
+// CashFlows returns the dates and amounts of the coupons and redemption paid after settlement, per 100 face value.
+func (b FixedRateBond) CashFlows(settlement time.Time) ([]time.Time, []float64, error) {
+	dates, err := b.couponDates(settlement)
+	if err != nil {
+		return nil, nil, err
+	}
+	amounts := make([]float64, len(dates)-1)
+	for i := range amounts {
+		amounts[i] = 100 * b.Coupon / float64(b.Frequency)
+	}
+	amounts[len(amounts)-1] += b.Redemption
+	return dates[1:], amounts, nil
+}
+
+// priceTo returns the clean price of the bond at settlement, assuming it's redeemed at redemptionPrice (plus the accrued interest) on redemptionDate
+func (b FixedRateBond) priceTo(settlement time.Time, yield float64, redemptionDate time.Time, redemptionPrice float64) (float64, error) {
+	dates, err := b.couponDates(settlement)
+	if err != nil {
+		return 0, err
+	}
+	redemptionDate = civilDate(redemptionDate)
+	if !redemptionDate.After(civilDate(settlement)) || redemptionDate.After(dates[len(dates)-1]) {
+		return 0, errors.New("the redemption date must fall after settlement and no later than maturity")
+	}
+	coupon := 100 * b.Coupon / float64(b.Frequency)
+	accrued := b.accrued(dates[0], dates[1], settlement)
+	f := float64(b.Frequency)
+
+	// redemption within the current coupon period: simple interest
+	if !redemptionDate.After(dates[1]) {
+		amount := redemptionPrice + b.accrued(dates[0], dates[1], redemptionDate)
+		if redemptionDate.Equal(dates[1]) {
+			amount = redemptionPrice + coupon
+		}
+		return amount/(1+b.periods(dates, settlement, redemptionDate)*yield/f) - accrued, nil
+	}
+
+	v := 1 + yield/f
+	price := 0.0
+	for i := 1; i < len(dates) && dates[i].Before(redemptionDate); i++ {
+		price += coupon / math.Pow(v, b.periods(dates, settlement, dates[i]))
+	}
+	amount := redemptionPrice + coupon
+	for i := 1; i < len(dates); i++ {
+		if dates[i-1].Before(redemptionDate) && redemptionDate.Before(dates[i]) {
+			amount = redemptionPrice + b.accrued(dates[i-1], dates[i], redemptionDate)
+		}
+	}
+	return price + amount/math.Pow(v, b.periods(dates, settlement, redemptionDate)) - accrued, nil
+}
+
+// yieldTo returns the yield of the bond at settlement for the given clean price, assuming it's redeemed at redemptionPrice on redemptionDate
+func (b FixedRateBond) yieldTo(settlement time.Time, price float64, redemptionDate time.Time, redemptionPrice float64) (float64, error) {
+	if price <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+	if _, err := b.priceTo(settlement, 0, redemptionDate, redemptionPrice); err != nil {
+		return 0, err
+	}
+	function := func(yield float64) float64 {
+		p, _ := b.priceTo(settlement, yield, redemptionDate, redemptionPrice)
+		return p - price
+	}
+	a, c, err := bracket(function, 0, 0.1)
+	if err != nil {
+		return 0, err
+	}
+	return brent(function, a, c)
+}
+
+// periods returns the number of coupon periods between settlement and date, as used for discounting.
+// dates are the coupon dates as returned by couponDates.
+func (b FixedRateBond) periods(dates []time.Time, settlement time.Time, date time.Time) float64 {
+	days := b.quasiCouponDays(dates[0], dates[1])
+	var dsc float64
+	if b.Basis == CountNasd || b.Basis == CountEuropean {
+		dsc = days - float64(DaysDifference(dates[0].Unix(), civilDate(settlement).Unix(), b.Basis))
+	} else {
+		dsc = float64(actualDays(civilDate(settlement), dates[1]))
+	}
+	for i := 1; i < len(dates); i++ {
+		if date.Equal(dates[i]) {
+			return dsc/days + float64(i-1)
+		}
+		if date.Before(dates[i]) {
+			if i == 1 {
+				return float64(DaysDifference(civilDate(settlement).Unix(), date.Unix(), b.Basis)) / days
+			}
+			return dsc/days + float64(i-2) + float64(DaysDifference(dates[i-1].Unix(), date.Unix(), b.Basis))/b.quasiCouponDays(dates[i-1], dates[i])
+		}
+	}
+	return dsc/days + float64(len(dates)-2)
+}
+
+// accrued returns the interest accrued at date within the coupon period between start and end
+func (b FixedRateBond) accrued(start time.Time, end time.Time, date time.Time) float64 {
+	days := float64(DaysDifference(start.Unix(), civilDate(date).Unix(), b.Basis))
+	return 100 * b.Coupon / float64(b.Frequency) * days / b.quasiCouponDays(start, end)
+}
+
+func (b FixedRateBond) quasiCouponDays(start time.Time, end time.Time) float64 {
+	return quasiCouponDays(start, end, b.Frequency, b.Basis)
+}
+
+// couponDates returns the coupon date preceding settlement (or settlement itself if it's a coupon date), followed by the coupon dates after settlement
+func (b FixedRateBond) couponDates(settlement time.Time) ([]time.Time, error) {
+	if !isValidBasis(b.Basis) {
+		return nil, errors.New("invalid day count basis")
+	}
+	dates, err := scheduleDates(settlement, b.Maturity, b.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	maturity := civilDate(b.Maturity)
+	dates[0] = addMonths(maturity, -(len(dates)-1)*12/b.Frequency, isEndOfMonth(maturity))
+	return dates, nil
+}
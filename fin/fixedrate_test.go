@@ -0,0 +1,80 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFixedRateBondPrice(t *testing.T) {
+	var tests = []struct {
+		bond       FixedRateBond
+		settlement time.Time
+		yield      float64
+		want       float64
+	}{
+		{FixedRateBond{Maturity: date(2017, time.November, 15), Coupon: 0.0575, Frequency: 2, Basis: CountNasd, Redemption: 100}, date(2008, time.February, 15), 0.065, 94.634362},
+		// at par on a coupon date
+		{FixedRateBond{Maturity: date(2030, time.June, 30), Coupon: 0.04, Frequency: 4, Basis: CountActualActual, Redemption: 100}, date(2020, time.March, 31), 0.04, 100},
+		// last coupon period: simple interest
+		{FixedRateBond{Maturity: date(2020, time.June, 15), Coupon: 0.05, Frequency: 2, Basis: CountNasd, Redemption: 100}, date(2020, time.March, 15), 0.06, 99.735222},
+	}
+
+	for _, test := range tests {
+		if got, err := test.bond.Price(test.settlement, test.yield); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("%v.Price(%v, %f) = %f, %v", test.bond, test.settlement, test.yield, got, err)
+		}
+	}
+
+	bond := FixedRateBond{Maturity: date(2017, time.November, 15), Coupon: 0.0575, Frequency: 2, Basis: CountNasd, Redemption: 100}
+	if _, err := bond.Price(date(2018, time.January, 1), 0.065); err == nil {
+		t.Error("A settlement after maturity should return an error")
+	}
+	bond.Frequency = 5
+	if _, err := bond.Price(date(2008, time.February, 15), 0.065); err == nil {
+		t.Error("An invalid frequency should return an error")
+	}
+}
+
+func TestFixedRateBondYield(t *testing.T) {
+	var tests = []struct {
+		bond       FixedRateBond
+		settlement time.Time
+		price      float64
+		want       float64
+	}{
+		{FixedRateBond{Maturity: date(2016, time.November, 15), Coupon: 0.0575, Frequency: 2, Basis: CountNasd, Redemption: 100}, date(2008, time.February, 15), 95.04287, 0.065},
+		{FixedRateBond{Maturity: date(2020, time.June, 15), Coupon: 0.05, Frequency: 2, Basis: CountNasd, Redemption: 100}, date(2020, time.March, 15), 99.735222, 0.06},
+	}
+
+	for _, test := range tests {
+		if got, err := test.bond.Yield(test.settlement, test.price); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("%v.Yield(%v, %f) = %f, %v", test.bond, test.settlement, test.price, got, err)
+		}
+	}
+}
+
+func TestFixedRateBondAccruedInterest(t *testing.T) {
+	bond := FixedRateBond{Maturity: date(2017, time.November, 15), Coupon: 0.0575, Frequency: 2, Basis: CountNasd, Redemption: 100}
+	if got, err := bond.AccruedInterest(date(2008, time.February, 15)); err != nil || math.Abs(1.4375-got) > Precision {
+		t.Errorf("%v.AccruedInterest(%v) = %f, %v", bond, date(2008, time.February, 15), got, err)
+	}
+}
+
+func TestFixedRateBondCashFlows(t *testing.T) {
+	bond := FixedRateBond{Maturity: date(2010, time.May, 31), Coupon: 0.06, Frequency: 2, Basis: CountNasd, Redemption: 100}
+	dates, amounts, err := bond.CashFlows(date(2009, time.January, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDates := []time.Time{date(2009, time.May, 31), date(2009, time.November, 30), date(2010, time.May, 31)}
+	wantAmounts := []float64{3, 3, 103}
+	if len(dates) != len(wantDates) {
+		t.Fatalf("%v.CashFlows(%v) = %v, %v", bond, date(2009, time.January, 10), dates, amounts)
+	}
+	for i := range dates {
+		if !dates[i].Equal(wantDates[i]) || math.Abs(wantAmounts[i]-amounts[i]) > Precision {
+			t.Errorf("%v.CashFlows(%v) = %v, %v", bond, date(2009, time.January, 10), dates, amounts)
+		}
+	}
+}
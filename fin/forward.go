@@ -0,0 +1,109 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// These constants are used in the forward rate functions (parameter "compounding"), for specifying how interest rates are compounded:
+const (
+	// Simple interest: 1 + rate*t
+	CompoundSimple = iota
+	// Compounded frequency times per year: (1 + rate/frequency)^(frequency*t)
+	CompoundPeriodic
+	// Continuously compounded: e^(rate*t)
+	CompoundContinuous
+)
+
+// ForwardRate returns the forward rate between years1 and years2 implied by the spot rates for those maturities.
+// All the rates use the same compounding (see the Compound* constants). Frequency is the number of compounding periods per year, only used with CompoundPeriodic.
+func ForwardRate(spot1 float64, years1 float64, spot2 float64, years2 float64, compounding int, frequency int) (float64, error) {
+	if err := validateCompounding(compounding, frequency); err != nil {
+		return 0, err
+	}
+	if years1 < 0 || years2 <= years1 {
+		return 0, errors.New("maturities must be positive and years1 must be lower than years2")
+	}
+	growth := growthFactor(spot2, years2, compounding, frequency) / growthFactor(spot1, years1, compounding, frequency)
+	return rateFromGrowth(growth, years2-years1, compounding, frequency), nil
+}
+
+// ForwardDiscountFactor returns the discount factor between times t1 and t2 (in years) implied by a curve, that is the value at t1 of one unit paid at t2.
+func ForwardDiscountFactor(curve DiscountCurve, t1 float64, t2 float64) (float64, error) {
+	if t2 < t1 {
+		return 0, errors.New("t1 can't be after t2")
+	}
+	return curve.DiscountFactor(t2) / curve.DiscountFactor(t1), nil
+}
+
+// ZeroRate returns the spot rate for maturity t (in years) implied by a curve, with the given compounding (see the Compound* constants).
+// Frequency is the number of compounding periods per year, only used with CompoundPeriodic.
+func ZeroRate(curve DiscountCurve, t float64, compounding int, frequency int) (float64, error) {
+	if err := validateCompounding(compounding, frequency); err != nil {
+		return 0, err
+	}
+	if t <= 0 {
+		return 0, errors.New("maturity must be positive")
+	}
+	return rateFromGrowth(1/curve.DiscountFactor(t), t, compounding, frequency), nil
+}
+
+// ForwardRateCurve returns the forward rate between start and end implied by a curve whose reference date is valuation.
+// The rate's accrual period is measured with the given daycount basis (see the Count* constants), and it uses the given compounding (see the Compound* constants).
+// Frequency is the number of compounding periods per year, only used with CompoundPeriodic.
+func ForwardRateCurve(curve DiscountCurve, valuation time.Time, start time.Time, end time.Time, basis int, compounding int, frequency int) (float64, error) {
+	if err := validateCompounding(compounding, frequency); err != nil {
+		return 0, err
+	}
+	if !isValidBasis(basis) {
+		return 0, errors.New("invalid day count basis")
+	}
+	if !civilDate(start).Before(civilDate(end)) {
+		return 0, errors.New("start must precede end")
+	}
+	growth := curve.DiscountFactor(curveTime(valuation, start)) / curve.DiscountFactor(curveTime(valuation, end))
+	return rateFromGrowth(growth, yearFraction(start, end, basis), compounding, frequency), nil
+}
+
+// ForwardRateAgreementValue returns the value at valuation of a forward rate agreement for the buyer, who pays contractRate and receives the simple
+// rate between start and end implied by a curve whose reference date is valuation. The accrual period is measured with the given daycount basis.
+func ForwardRateAgreementValue(curve DiscountCurve, valuation time.Time, start time.Time, end time.Time, contractRate float64, notional float64, basis int) (float64, error) {
+	forward, err := ForwardRateCurve(curve, valuation, start, end, basis, CompoundSimple, 0)
+	if err != nil {
+		return 0, err
+	}
+	return notional * (forward - contractRate) * yearFraction(start, end, basis) * curve.DiscountFactor(curveTime(valuation, end)), nil
+}
+
+// growthFactor returns the value after t years of one unit invested at rate
+func growthFactor(rate float64, t float64, compounding int, frequency int) float64 {
+	switch compounding {
+	case CompoundSimple:
+		return 1 + rate*t
+	case CompoundPeriodic:
+		return math.Pow(1+rate/float64(frequency), float64(frequency)*t)
+	}
+	return math.Exp(rate * t)
+}
+
+// rateFromGrowth returns the rate that makes one unit grow to growth after t years
+func rateFromGrowth(growth float64, t float64, compounding int, frequency int) float64 {
+	switch compounding {
+	case CompoundSimple:
+		return (growth - 1) / t
+	case CompoundPeriodic:
+		return float64(frequency) * (math.Pow(growth, 1/(float64(frequency)*t)) - 1)
+	}
+	return math.Log(growth) / t
+}
+
+func validateCompounding(compounding int, frequency int) error {
+	if compounding < CompoundSimple || compounding > CompoundContinuous {
+		return errors.New("invalid compounding")
+	}
+	if compounding == CompoundPeriodic && frequency <= 0 {
+		return errors.New("frequency must be strictly positive")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestForwardRate(t *testing.T) {
+	var tests = []struct {
+		spot1       float64
+		years1      float64
+		spot2       float64
+		years2      float64
+		compounding int
+		frequency   int
+		want        float64
+	}{
+		{0.05, 1, 0.06, 2, CompoundPeriodic, 1, 0.070095},
+		{0.05, 1, 0.06, 2, CompoundPeriodic, 2, 0.070049},
+		{0.05, 1, 0.06, 2, CompoundContinuous, 0, 0.07},
+		{0.05, 1, 0.06, 2, CompoundSimple, 0, 0.066667},
+		{0.05, 0, 0.06, 2, CompoundContinuous, 0, 0.06},
+	}
+
+	for _, test := range tests {
+		if got, _ := ForwardRate(test.spot1, test.years1, test.spot2, test.years2, test.compounding, test.frequency); math.Abs(test.want-got) > Precision {
+			t.Errorf("ForwardRate(%f, %f, %f, %f, %d, %d) = %f", test.spot1, test.years1, test.spot2, test.years2, test.compounding, test.frequency, got)
+		}
+	}
+
+	if _, err := ForwardRate(0.05, 2, 0.06, 1, CompoundContinuous, 0); err == nil {
+		t.Error("If years1 isn't lower than years2, it must return an error")
+	}
+	if _, err := ForwardRate(0.05, 1, 0.06, 2, CompoundPeriodic, 0); err == nil {
+		t.Error("A periodic compounding without frequency should return an error")
+	}
+	if _, err := ForwardRate(0.05, 1, 0.06, 2, 3, 0); err == nil {
+		t.Error("An invalid compounding should return an error")
+	}
+}
+
+func TestForwardDiscountFactor(t *testing.T) {
+	curve, _ := NewPiecewiseConstantCurve([]float64{1, 2}, []float64{0.05, 0.06})
+	if got, _ := ForwardDiscountFactor(curve, 1, 2); math.Abs(got-math.Exp(-0.06)) > Precision {
+		t.Errorf("ForwardDiscountFactor(%v, 1, 2) = %f", curve, got)
+	}
+
+	if _, err := ForwardDiscountFactor(curve, 2, 1); err == nil {
+		t.Error("If t1 is after t2, it must return an error")
+	}
+}
+
+func TestZeroRate(t *testing.T) {
+	var tests = []struct {
+		curve       DiscountCurve
+		t           float64
+		compounding int
+		frequency   int
+		want        float64
+	}{
+		{FlatCurve{0.05}, 2, CompoundPeriodic, 1, 0.05},
+		{FlatCurve{0.05}, 2, CompoundContinuous, 0, 0.048790},
+		{FlatCurve{0.05}, 1, CompoundSimple, 0, 0.05},
+	}
+
+	for _, test := range tests {
+		if got, _ := ZeroRate(test.curve, test.t, test.compounding, test.frequency); math.Abs(test.want-got) > Precision {
+			t.Errorf("ZeroRate(%v, %f, %d, %d) = %f", test.curve, test.t, test.compounding, test.frequency, got)
+		}
+	}
+
+	if _, err := ZeroRate(FlatCurve{0.05}, 0, CompoundContinuous, 0); err == nil {
+		t.Error("A maturity that isn't positive should return an error")
+	}
+}
+
+func TestForwardRateCurve(t *testing.T) {
+	valuation := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		basis       int
+		compounding int
+		frequency   int
+		want        float64
+	}{
+		{CountActual365, CompoundPeriodic, 1, 0.04},
+		{CountActual360, CompoundSimple, 0, 0.039062},
+	}
+
+	for _, test := range tests {
+		if got, _ := ForwardRateCurve(FlatCurve{0.04}, valuation, start, end, test.basis, test.compounding, test.frequency); math.Abs(test.want-got) > Precision {
+			t.Errorf("ForwardRateCurve(FlatCurve{0.04}, %v, %v, %v, %d, %d, %d) = %f", valuation, start, end, test.basis, test.compounding, test.frequency, got)
+		}
+	}
+
+	if _, err := ForwardRateCurve(FlatCurve{0.04}, valuation, end, start, CountActual360, CompoundSimple, 0); err == nil {
+		t.Error("If start doesn't precede end, it must return an error")
+	}
+}
+
+func TestForwardRateAgreementValue(t *testing.T) {
+	valuation := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if got, _ := ForwardRateAgreementValue(FlatCurve{0.04}, valuation, start, end, 0.035, 1e6, CountActual360); math.Abs(got-1925.738296) > Precision {
+		t.Errorf("ForwardRateAgreementValue(FlatCurve{0.04}, %v, %v, %v, %f, %f, %d) = %f", valuation, start, end, 0.035, 1e6, CountActual360, got)
+	}
+
+	// at the forward rate the FRA is worth zero
+	forward, _ := ForwardRateCurve(FlatCurve{0.04}, valuation, start, end, CountActual360, CompoundSimple, 0)
+	if got, _ := ForwardRateAgreementValue(FlatCurve{0.04}, valuation, start, end, forward, 1e6, CountActual360); math.Abs(got) > Precision {
+		t.Errorf("A FRA at the forward rate should be worth zero, got %f", got)
+	}
+}
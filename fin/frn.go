@@ -0,0 +1,160 @@
+package fin
+
+import (
+	"errors"
+	"time"
+)
+
+// OvernightConventions are the conventions used to compound an overnight rate (such as SOFR or ESTR) in arrears over an interest period.
+type OvernightConventions struct {
+	// Calendar determines the business days on which the rate is published
+	Calendar Calendar
+	// Lookback is the number of business days by which the observation of each rate is shifted back
+	Lookback int
+	// Lockout is the number of business days at the end of the period during which the rate stays fixed at the last one observed before them
+	Lockout int
+	// ObservationShift determines whether the daily weights are taken from the (shifted back) observation period instead of the interest period
+	ObservationShift bool
+	// Basis is the daycount basis of the rate, either CountActual360 or CountActual365
+	Basis int
+}
+
+// CompoundedOvernightRate returns the annualized rate obtained by compounding daily an overnight rate over the interest period between start and end.
+//
+// fixingDates and fixingRates are the published overnight rates; there must be one for each observed business day.
+func CompoundedOvernightRate(fixingDates []time.Time, fixingRates []float64, start time.Time, end time.Time, conventions OvernightConventions) (float64, error) {
+	if len(fixingDates) != len(fixingRates) {
+		return 0, errors.New("fixingDates and fixingRates must have the same length")
+	}
+	if conventions.Basis != CountActual360 && conventions.Basis != CountActual365 {
+		return 0, errors.New("the basis must be actual/360 or actual/365")
+	}
+	if conventions.Lookback < 0 || conventions.Lockout < 0 {
+		return 0, errors.New("lookback and lockout can't be negative")
+	}
+	start, end = civilDate(start), civilDate(end)
+	if !start.Before(end) {
+		return 0, errors.New("start must precede end")
+	}
+	fixings := make(map[time.Time]float64)
+	for i, date := range fixingDates {
+		fixings[civilDate(date)] = fixingRates[i]
+	}
+
+	calendar := conventions.Calendar
+	from, to := start, end
+	if conventions.ObservationShift {
+		from = calendar.AddBusinessDays(start, -conventions.Lookback)
+		to = calendar.AddBusinessDays(end, -conventions.Lookback)
+	}
+	days := make([]time.Time, 0)
+	for date := from; date.Before(to); date = date.AddDate(0, 0, 1) {
+		if calendar.IsBusinessDay(date) {
+			days = append(days, date)
+		}
+	}
+	if len(days) <= conventions.Lockout {
+		return 0, errors.New("the lockout must be shorter than the period")
+	}
+
+	rates := make([]float64, len(days))
+	for i, date := range days {
+		observed := date
+		if !conventions.ObservationShift {
+			observed = calendar.AddBusinessDays(date, -conventions.Lookback)
+		}
+		rate, ok := fixings[observed]
+		if !ok {
+			return 0, errors.New("missing fixing for " + observed.Format("2006-01-02"))
+		}
+		rates[i] = rate
+	}
+
+	yearDays := float64(DaysPerYear(0, conventions.Basis))
+	compounded := 1.0
+	for i, date := range days {
+		next := to
+		if i+1 < len(days) {
+			next = days[i+1]
+		}
+		rate := rates[i]
+		if i >= len(days)-conventions.Lockout {
+			rate = rates[len(days)-conventions.Lockout-1]
+		}
+		compounded *= 1 + rate*float64(actualDays(date, next))/yearDays
+	}
+	return (compounded - 1) * yearDays / float64(actualDays(from, to)), nil
+}
+
+// OvernightCoupon returns the coupon paid on notional over the interest period between start and end, at the overnight rate compounded in arrears plus margin.
+// See CompoundedOvernightRate for the meaning of the rest of the parameters.
+func OvernightCoupon(fixingDates []time.Time, fixingRates []float64, start time.Time, end time.Time, conventions OvernightConventions, notional float64, margin float64) (float64, error) {
+	rate, err := CompoundedOvernightRate(fixingDates, fixingRates, start, end, conventions)
+	if err != nil {
+		return 0, err
+	}
+	return notional * (rate + margin) * float64(actualDays(civilDate(start), civilDate(end))) / float64(DaysPerYear(0, conventions.Basis)), nil
+}
+
+// FloatingRateNote is a bond paying, Frequency times per year until Maturity, the index rate plus QuotedMargin, accrued with the given daycount basis.
+type FloatingRateNote struct {
+	Maturity     time.Time
+	Frequency    int
+	Basis        int
+	QuotedMargin float64
+	// CurrentRate is the index rate already fixed for the current coupon period
+	CurrentRate float64
+}
+
+// Price returns the full (dirty) price per 100 face value of the note at settlement, assuming the index stays at indexRate for the future coupons,
+// and discounting them at the index rate plus discountMargin.
+func (n FloatingRateNote) Price(settlement time.Time, indexRate float64, discountMargin float64) (float64, error) {
+	dates, err := n.couponDates(settlement)
+	if err != nil {
+		return 0, err
+	}
+	price, df := 0.0, 1.0
+	for i := 1; i < len(dates); i++ {
+		rate := indexRate
+		if i == 1 {
+			rate = n.CurrentRate
+		}
+		discountFrom := dates[i-1]
+		if i == 1 {
+			discountFrom = civilDate(settlement)
+		}
+		df /= 1 + (indexRate+discountMargin)*yearFraction(discountFrom, dates[i], n.Basis)
+		price += 100 * (rate + n.QuotedMargin) * yearFraction(dates[i-1], dates[i], n.Basis) * df
+	}
+	return price + 100*df, nil
+}
+
+// DiscountMargin returns the spread over the index rate that discounts the note's coupons to the given full (dirty) price per 100 face value,
+// assuming the index stays at indexRate for the future coupons.
+func (n FloatingRateNote) DiscountMargin(settlement time.Time, indexRate float64, price float64) (float64, error) {
+	if _, err := n.couponDates(settlement); err != nil {
+		return 0, err
+	}
+	function := func(margin float64) float64 {
+		p, _ := n.Price(settlement, indexRate, margin)
+		return p - price
+	}
+	a, b, err := bracket(function, n.QuotedMargin-0.01, n.QuotedMargin+0.01)
+	if err != nil {
+		return 0, err
+	}
+	return brent(function, a, b)
+}
+
+// couponDates returns the previous coupon date followed by the remaining coupon dates after settlement
+func (n FloatingRateNote) couponDates(settlement time.Time) ([]time.Time, error) {
+	if !isValidBasis(n.Basis) {
+		return nil, errors.New("invalid day count basis")
+	}
+	dates, err := scheduleDates(settlement, n.Maturity, n.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	dates[0] = addMonths(dates[1], -12/n.Frequency, isEndOfMonth(civilDate(n.Maturity)))
+	return dates, nil
+}
@@ -0,0 +1,96 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// overnightFixings returns a rate for every weekday between April and July 2020, increasing by one basis point each day
+func overnightFixings() ([]time.Time, []float64) {
+	dates := make([]time.Time, 0)
+	rates := make([]float64, 0)
+	for d := date(2020, time.April, 1); d.Before(date(2020, time.August, 1)); d = d.AddDate(0, 0, 1) {
+		if (Calendar{}).IsBusinessDay(d) {
+			dates = append(dates, d)
+			rates = append(rates, 0.01+0.0001*float64(len(rates)))
+		}
+	}
+	return dates, rates
+}
+
+func TestCompoundedOvernightRate(t *testing.T) {
+	dates, rates := overnightFixings()
+	start, end := date(2020, time.June, 1), date(2020, time.July, 1)
+	var tests = []struct {
+		conventions OvernightConventions
+		want        float64
+	}{
+		{OvernightConventions{Basis: CountActual360}, 0.015386},
+		{OvernightConventions{Lookback: 2, Basis: CountActual360}, 0.015186},
+		{OvernightConventions{Lookback: 2, ObservationShift: true, Basis: CountActual360}, 0.015175},
+		{OvernightConventions{Lockout: 2, Basis: CountActual360}, 0.015376},
+		{OvernightConventions{Lookback: 5, Lockout: 2, ObservationShift: true, Basis: CountActual360}, 0.014875},
+	}
+
+	for _, test := range tests {
+		if got, err := CompoundedOvernightRate(dates, rates, start, end, test.conventions); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("CompoundedOvernightRate(%v, %v, %+v) = %f, %v", start, end, test.conventions, got, err)
+		}
+	}
+
+	// a constant rate compounds to a slightly higher one
+	constant := make([]float64, len(rates))
+	for i := range constant {
+		constant[i] = 0.02
+	}
+	if got, _ := CompoundedOvernightRate(dates, constant, start, end, OvernightConventions{Basis: CountActual360}); got <= 0.02 || got > 0.0201 {
+		t.Errorf("CompoundedOvernightRate with a constant rate = %f", got)
+	}
+
+	if _, err := CompoundedOvernightRate(dates, rates, date(2020, time.July, 1), date(2020, time.August, 5), OvernightConventions{Basis: CountActual360}); err == nil {
+		t.Error("If a fixing is missing, it must return an error")
+	}
+	if _, err := CompoundedOvernightRate(dates, rates, start, end, OvernightConventions{Basis: CountNasd}); err == nil {
+		t.Error("An invalid basis should return an error")
+	}
+	if _, err := CompoundedOvernightRate(dates, rates, start, start.AddDate(0, 0, 1), OvernightConventions{Lockout: 1, Basis: CountActual360}); err == nil {
+		t.Error("If the lockout isn't shorter than the period, it must return an error")
+	}
+}
+
+func TestOvernightCoupon(t *testing.T) {
+	dates, rates := overnightFixings()
+	start, end := date(2020, time.June, 1), date(2020, time.July, 1)
+	rate, _ := CompoundedOvernightRate(dates, rates, start, end, OvernightConventions{Basis: CountActual360})
+	if got, _ := OvernightCoupon(dates, rates, start, end, OvernightConventions{Basis: CountActual360}, 1e6, 0.001); math.Abs(got-1e6*(rate+0.001)*30/360) > Precision {
+		t.Errorf("OvernightCoupon(%v, %v) = %f", start, end, got)
+	}
+}
+
+func TestFloatingRateNote(t *testing.T) {
+	note := FloatingRateNote{
+		Maturity:     date(2023, time.March, 15),
+		Frequency:    4,
+		Basis:        CountActual360,
+		QuotedMargin: 0.005,
+		CurrentRate:  0.012,
+	}
+	settlement := date(2020, time.May, 1)
+	if got, _ := note.Price(settlement, 0.015, 0.006); math.Abs(got-99.902280) > Precision {
+		t.Errorf("FloatingRateNote.Price(%v, %f, %f) = %f", settlement, 0.015, 0.006, got)
+	}
+	if got, _ := note.DiscountMargin(settlement, 0.015, 99.902280); math.Abs(got-0.006) > Precision {
+		t.Errorf("FloatingRateNote.DiscountMargin(%v, %f, %f) = %f", settlement, 0.015, 99.902280, got)
+	}
+
+	// on a coupon date, a note whose discount margin equals its quoted margin is priced at par
+	note.CurrentRate = 0.015
+	if got, _ := note.Price(date(2020, time.March, 15), 0.015, 0.005); math.Abs(got-100) > Precision {
+		t.Errorf("FloatingRateNote.Price() at par = %f", got)
+	}
+
+	if _, err := note.Price(date(2024, time.March, 15), 0.015, 0.005); err == nil {
+		t.Error("If settlement isn't before maturity, it must return an error")
+	}
+}
@@ -0,0 +1,134 @@
+package fin
+
+import (
+	"errors"
+	"time"
+)
+
+// In the fund performance functions, values are the cash flows from the investor's (LP) perspective, as fed to ScheduledInternalRateOfReturn:
+// capital calls (paid-in capital) are negative values and distributions are positive values.
+// nav is the fund's residual net asset value at navDate, the end of the measurement period.
+//
+// The public market equivalents compare the fund against an index, whose levels on each of the cash flow dates are given in indexValues,
+// and whose level on navDate is navIndex.
+
+// TotalValueToPaidIn returns the TVPI multiple of a fund: distributions plus residual value, divided by paid-in capital.
+func TotalValueToPaidIn(values []float64, nav float64) (float64, error) {
+	paidIn, distributed, err := paidInAndDistributed(values)
+	if err != nil {
+		return 0, err
+	}
+	return (distributed + nav) / paidIn, nil
+}
+
+// DistributedToPaidIn returns the DPI multiple (realization multiple) of a fund: distributions divided by paid-in capital.
+func DistributedToPaidIn(values []float64) (float64, error) {
+	paidIn, distributed, err := paidInAndDistributed(values)
+	if err != nil {
+		return 0, err
+	}
+	return distributed / paidIn, nil
+}
+
+// ResidualValueToPaidIn returns the RVPI multiple of a fund: residual value divided by paid-in capital.
+func ResidualValueToPaidIn(values []float64, nav float64) (float64, error) {
+	paidIn, _, err := paidInAndDistributed(values)
+	if err != nil {
+		return 0, err
+	}
+	return nav / paidIn, nil
+}
+
+// FundInternalRateOfReturn returns the net IRR of a fund, treating its residual value as a final distribution on navDate.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+func FundInternalRateOfReturn(values []float64, dates []time.Time, nav float64, navDate time.Time, guess float64) (float64, error) {
+	if len(values) != len(dates) {
+		return 0, errors.New("values and dates must have the same length")
+	}
+	return ScheduledInternalRateOfReturn(append(append([]float64{}, values...), nav), append(append([]time.Time{}, dates...), navDate), guess)
+}
+
+// LongNickelsPublicMarketEquivalent returns the Long-Nickels PME: the IRR obtained by investing the fund's cash flows in the index,
+// using the fund's cash flows together with the resulting index portfolio value on navDate as the final value.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+func LongNickelsPublicMarketEquivalent(values []float64, dates []time.Time, indexValues []float64, nav float64, navDate time.Time, navIndex float64, guess float64) (float64, error) {
+	if err := validateFundIndex(values, dates, indexValues, navIndex); err != nil {
+		return 0, err
+	}
+	pmeNav := 0.0
+	for i, value := range values {
+		pmeNav -= value * navIndex / indexValues[i]
+	}
+	return ScheduledInternalRateOfReturn(append(append([]float64{}, values...), pmeNav), append(append([]time.Time{}, dates...), navDate), guess)
+}
+
+// KaplanSchoarPublicMarketEquivalent returns the Kaplan-Schoar PME: the ratio between the index-compounded distributions plus residual value,
+// and the index-compounded paid-in capital. A value greater than one means the fund outperformed the index.
+func KaplanSchoarPublicMarketEquivalent(values []float64, indexValues []float64, nav float64, navIndex float64) (float64, error) {
+	if len(values) != len(indexValues) {
+		return 0, errors.New("values and indexValues must have the same length")
+	}
+	if err := validateIndex(indexValues, navIndex); err != nil {
+		return 0, err
+	}
+	compounded := make([]float64, len(values))
+	for i, value := range values {
+		compounded[i] = value * navIndex / indexValues[i]
+	}
+	paidIn, distributed, err := paidInAndDistributed(compounded)
+	if err != nil {
+		return 0, err
+	}
+	return (distributed + nav) / paidIn, nil
+}
+
+// DirectAlpha returns the annual (discretely compounded) excess return of a fund over the index,
+// computed as the IRR of the fund's cash flows compounded with the index returns up to navDate.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+func DirectAlpha(values []float64, dates []time.Time, indexValues []float64, nav float64, navDate time.Time, navIndex float64, guess float64) (float64, error) {
+	if err := validateFundIndex(values, dates, indexValues, navIndex); err != nil {
+		return 0, err
+	}
+	compounded := make([]float64, len(values))
+	for i, value := range values {
+		compounded[i] = value * navIndex / indexValues[i]
+	}
+	return ScheduledInternalRateOfReturn(append(compounded, nav), append(append([]time.Time{}, dates...), navDate), guess)
+}
+
+func paidInAndDistributed(values []float64) (float64, float64, error) {
+	paidIn, distributed := 0.0, 0.0
+	for _, value := range values {
+		if value < 0 {
+			paidIn -= value
+		} else {
+			distributed += value
+		}
+	}
+	if paidIn == 0 {
+		return 0, 0, errors.New("the cash flow must contain at least one capital call (negative value)")
+	}
+	return paidIn, distributed, nil
+}
+
+func validateFundIndex(values []float64, dates []time.Time, indexValues []float64, navIndex float64) error {
+	if len(values) != len(dates) {
+		return errors.New("values and dates must have the same length")
+	}
+	if len(values) != len(indexValues) {
+		return errors.New("values and indexValues must have the same length")
+	}
+	return validateIndex(indexValues, navIndex)
+}
+
+func validateIndex(indexValues []float64, navIndex float64) error {
+	if navIndex <= 0 {
+		return errors.New("index values must be positive")
+	}
+	for _, value := range indexValues {
+		if value <= 0 {
+			return errors.New("index values must be positive")
+		}
+	}
+	return nil
+}
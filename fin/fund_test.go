@@ -0,0 +1,75 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	fundValues = []float64{-100, -50, 30, 80}
+	fundDates  = []time.Time{
+		time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}
+	fundIndex    = []float64{100, 110, 130, 140}
+	fundNav      = 90.0
+	fundNavDate  = time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)
+	fundNavIndex = 160.0
+)
+
+func TestFundMultiples(t *testing.T) {
+	if got, _ := TotalValueToPaidIn(fundValues, fundNav); math.Abs(got-1.333333) > Precision {
+		t.Errorf("TotalValueToPaidIn(%v, %f) = %f", fundValues, fundNav, got)
+	}
+	if got, _ := DistributedToPaidIn(fundValues); math.Abs(got-0.733333) > Precision {
+		t.Errorf("DistributedToPaidIn(%v) = %f", fundValues, got)
+	}
+	if got, _ := ResidualValueToPaidIn(fundValues, fundNav); math.Abs(got-0.6) > Precision {
+		t.Errorf("ResidualValueToPaidIn(%v, %f) = %f", fundValues, fundNav, got)
+	}
+
+	if _, err := TotalValueToPaidIn([]float64{30, 80}, fundNav); err == nil {
+		t.Error("If there's no paid-in capital, it must return an error")
+	}
+}
+
+func TestFundInternalRateOfReturn(t *testing.T) {
+	if got, _ := FundInternalRateOfReturn(fundValues, fundDates, fundNav, fundNavDate, 0.1); math.Abs(got-0.064723) > Precision {
+		t.Errorf("FundInternalRateOfReturn(%v, %v, %f, %v, %f) = %f", fundValues, fundDates, fundNav, fundNavDate, 0.1, got)
+	}
+
+	if _, err := FundInternalRateOfReturn(fundValues, fundDates[1:], fundNav, fundNavDate, 0.1); err == nil {
+		t.Error("If values and dates have different lengths, it must return an error")
+	}
+}
+
+func TestPublicMarketEquivalents(t *testing.T) {
+	if got, _ := LongNickelsPublicMarketEquivalent(fundValues, fundDates, fundIndex, fundNav, fundNavDate, fundNavIndex, 0.1); math.Abs(got-0.079799) > Precision {
+		t.Errorf("LongNickelsPublicMarketEquivalent(%v, %v, %v, %f, %v, %f, %f) = %f", fundValues, fundDates, fundIndex, fundNav, fundNavDate, fundNavIndex, 0.1, got)
+	}
+	if got, _ := KaplanSchoarPublicMarketEquivalent(fundValues, fundIndex, fundNav, fundNavIndex); math.Abs(got-0.938230) > Precision {
+		t.Errorf("KaplanSchoarPublicMarketEquivalent(%v, %v, %f, %f) = %f", fundValues, fundIndex, fundNav, fundNavIndex, got)
+	}
+	if got, _ := DirectAlpha(fundValues, fundDates, fundIndex, fundNav, fundNavDate, fundNavIndex, 0.1); math.Abs(got+0.013889) > Precision {
+		t.Errorf("DirectAlpha(%v, %v, %v, %f, %v, %f, %f) = %f", fundValues, fundDates, fundIndex, fundNav, fundNavDate, fundNavIndex, 0.1, got)
+	}
+
+	// a flat index makes the direct alpha equal to the fund's IRR and the KS-PME equal to the TVPI
+	flat := []float64{100, 100, 100, 100}
+	if got, _ := DirectAlpha(fundValues, fundDates, flat, fundNav, fundNavDate, 100, 0.1); math.Abs(got-0.064723) > Precision {
+		t.Errorf("DirectAlpha with a flat index = %f", got)
+	}
+	if got, _ := KaplanSchoarPublicMarketEquivalent(fundValues, flat, fundNav, 100); math.Abs(got-1.333333) > Precision {
+		t.Errorf("KaplanSchoarPublicMarketEquivalent with a flat index = %f", got)
+	}
+
+	if _, err := KaplanSchoarPublicMarketEquivalent(fundValues, []float64{100, 0, 130, 140}, fundNav, fundNavIndex); err == nil {
+		t.Error("If an index value isn't positive, it must return an error")
+	}
+	if _, err := LongNickelsPublicMarketEquivalent(fundValues, fundDates, fundIndex[1:], fundNav, fundNavDate, fundNavIndex, 0.1); err == nil {
+		t.Error("If values and indexValues have different lengths, it must return an error")
+	}
+}
@@ -0,0 +1,142 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// HullWhite is the Hull-White one-factor model of the short rate, dr = (θ(t) - a r) dt + σ dW, where θ(t) is fitted so that the model reprices Curve.
+// It's implemented as a trinomial tree, on which instruments with early exercise rights are valued by backward induction.
+type HullWhite struct {
+	// Curve is the term structure the model is fitted to
+	Curve DiscountCurve
+	// MeanReversion is the speed a at which the short rate reverts to its mean
+	MeanReversion float64
+	// Volatility is the (normal) volatility σ of the short rate
+	Volatility float64
+	// StepsPerYear is the minimum number of time steps per year of the tree. Cash flow and exercise times always fall on a step.
+	StepsPerYear int
+}
+
+// trinomialTree is a recombining tree of the short rate r = alpha[i] + j*dx[i], where i is the time step and j the node, from -width[i] to width[i].
+type trinomialTree struct {
+	times []float64
+	dx    []float64
+	width []int
+	alpha []float64
+	// successor[i][j] is the middle node at step i+1 reached from node j at step i
+	successor [][]int
+	// probabilities[i][j] are the probabilities of moving down, to the middle and up from node j at step i
+	probabilities [][][3]float64
+}
+
+// tree returns the model's trinomial tree with steps on the given times, which must be increasing and start at zero.
+func (m HullWhite) tree(times []float64) (*trinomialTree, error) {
+	if m.Curve == nil {
+		return nil, errors.New("the model needs a discount curve")
+	}
+	if m.Volatility <= 0 {
+		return nil, errors.New("volatility must be positive")
+	}
+	if m.MeanReversion < 0 {
+		return nil, errors.New("mean reversion can't be negative")
+	}
+	n := len(times) - 1
+	tree := &trinomialTree{
+		times:         times,
+		dx:            make([]float64, n+1),
+		width:         make([]int, n+1),
+		alpha:         make([]float64, n),
+		successor:     make([][]int, n),
+		probabilities: make([][][3]float64, n),
+	}
+	// arrow-debreu prices of the nodes at the current step
+	prices := []float64{1}
+	for i := 0; i < n; i++ {
+		dt := times[i+1] - times[i]
+		decay, variance := math.Exp(-m.MeanReversion*dt), m.Volatility*m.Volatility*dt
+		if m.MeanReversion > 0 {
+			variance = m.Volatility * m.Volatility * (1 - decay*decay) / (2 * m.MeanReversion)
+		}
+		tree.dx[i+1] = math.Sqrt(3 * variance)
+
+		width := tree.width[i]
+		sum := 0.0
+		for j := -width; j <= width; j++ {
+			sum += prices[j+width] * math.Exp(-float64(j)*tree.dx[i]*dt)
+		}
+		tree.alpha[i] = math.Log(sum/m.Curve.DiscountFactor(times[i+1])) / dt
+
+		tree.successor[i] = make([]int, 2*width+1)
+		tree.probabilities[i] = make([][3]float64, 2*width+1)
+		for j := -width; j <= width; j++ {
+			mean := float64(j) * tree.dx[i] * decay
+			k := int(math.Round(mean / tree.dx[i+1]))
+			eta := mean/tree.dx[i+1] - float64(k)
+			tree.successor[i][j+width] = k
+			tree.probabilities[i][j+width] = [3]float64{1.0/6 + eta*eta/2 - eta/2, 2.0/3 - eta*eta, 1.0/6 + eta*eta/2 + eta/2}
+		}
+		tree.width[i+1] = tree.successor[i][2*width] + 1
+
+		next := make([]float64, 2*tree.width[i+1]+1)
+		for j := -width; j <= width; j++ {
+			discounted := prices[j+width] * tree.discount(i, j, 0)
+			k := tree.successor[i][j+width]
+			for b, p := range tree.probabilities[i][j+width] {
+				next[k+b-1+tree.width[i+1]] += p * discounted
+			}
+		}
+		prices = next
+	}
+	return tree, nil
+}
+
+// discount returns the one step discount factor from node j at step i, with spread added to the short rate
+func (t *trinomialTree) discount(i int, j int, spread float64) float64 {
+	return math.Exp(-(t.alpha[i] + float64(j)*t.dx[i] + spread) * (t.times[i+1] - t.times[i]))
+}
+
+// rollback returns the values at step i of the values at step i+1, discounted with spread added to the short rate
+func (t *trinomialTree) rollback(values []float64, i int, spread float64) []float64 {
+	width := t.width[i]
+	result := make([]float64, 2*width+1)
+	for j := -width; j <= width; j++ {
+		k := t.successor[i][j+width]
+		expected := 0.0
+		for b, p := range t.probabilities[i][j+width] {
+			expected += p * values[k+b-1+t.width[i+1]]
+		}
+		result[j+width] = expected * t.discount(i, j, spread)
+	}
+	return result
+}
+
+// treeTimes returns the times of the steps of a tree going through zero and all the given times (which must be positive),
+// and the step of each one of them. Consecutive times are split evenly into steps no longer than 1/stepsPerYear.
+func treeTimes(times []float64, stepsPerYear int) ([]float64, []int, error) {
+	if stepsPerYear < 1 {
+		return nil, nil, errors.New("there must be at least one step per year")
+	}
+	sorted := append([]float64{0}, times...)
+	sort.Float64s(sorted)
+	grid := []float64{0}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] <= 0 {
+			return nil, nil, errors.New("times must be positive")
+		}
+		if sorted[i] == sorted[i-1] {
+			continue
+		}
+		steps := int(math.Ceil((sorted[i] - sorted[i-1]) * float64(stepsPerYear)))
+		for s := 1; s < steps; s++ {
+			grid = append(grid, sorted[i-1]+(sorted[i]-sorted[i-1])*float64(s)/float64(steps))
+		}
+		grid = append(grid, sorted[i])
+	}
+	steps := make([]int, len(times))
+	for i, time := range times {
+		steps[i] = sort.SearchFloat64s(grid, time)
+	}
+	return grid, steps, nil
+}
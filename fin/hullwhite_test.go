@@ -0,0 +1,76 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHullWhiteTree(t *testing.T) {
+	curve, err := BootstrapCurve(curveReference, curveQuotes, InterpolationMonotoneConvex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, _, err := treeTimes([]float64{0.25, 1, 2.5, 7}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, model := range []HullWhite{
+		{Curve: curve, MeanReversion: 0.03, Volatility: 0.01, StepsPerYear: 4},
+		{Curve: curve, MeanReversion: 0, Volatility: 0.015, StepsPerYear: 4},
+	} {
+		tree, err := model.tree(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the tree reprices the zero-coupon bonds of the curve
+		for step := 1; step < len(grid); step++ {
+			values := make([]float64, 2*tree.width[step]+1)
+			for j := range values {
+				values[j] = 1
+			}
+			for i := step - 1; i >= 0; i-- {
+				values = tree.rollback(values, i, 0)
+			}
+			if want := curve.DiscountFactor(grid[step]); math.Abs(want-values[0]) > Precision {
+				t.Errorf("zero-coupon bond maturing at %f = %f, want %f", grid[step], values[0], want)
+			}
+		}
+		// probabilities are valid
+		for i := range tree.probabilities {
+			for _, p := range tree.probabilities[i] {
+				if p[0] < 0 || p[1] < 0 || p[2] < 0 || math.Abs(p[0]+p[1]+p[2]-1) > Precision {
+					t.Errorf("invalid probabilities %v at step %d", p, i)
+				}
+			}
+		}
+	}
+
+	if _, err := (HullWhite{Curve: curve, MeanReversion: 0.03, Volatility: 0, StepsPerYear: 4}).tree(grid); err == nil {
+		t.Error("A zero volatility should return an error")
+	}
+	if _, err := (HullWhite{MeanReversion: 0.03, Volatility: 0.01, StepsPerYear: 4}).tree(grid); err == nil {
+		t.Error("A missing curve should return an error")
+	}
+}
+
+func TestTreeTimes(t *testing.T) {
+	grid, steps, err := treeTimes([]float64{1, 0.3, 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{0, 0.3, 0.65, 1}
+	if len(grid) != len(want) {
+		t.Fatalf("treeTimes() = %v", grid)
+	}
+	for i := range grid {
+		if math.Abs(want[i]-grid[i]) > Precision {
+			t.Errorf("treeTimes() = %v", grid)
+		}
+	}
+	if steps[0] != 3 || steps[1] != 1 || steps[2] != 3 {
+		t.Errorf("treeTimes() steps = %v", steps)
+	}
+	if _, _, err := treeTimes([]float64{1}, 0); err == nil {
+		t.Error("Zero steps per year should return an error")
+	}
+}
@@ -0,0 +1,146 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// PriceIndex is a monthly price index, such as the CPI, used to adjust the cash flows of inflation-linked bonds.
+type PriceIndex struct {
+	values map[time.Time]float64
+}
+
+// NewPriceIndex returns a price index with values[i] as the value for the month of months[i] (the day of the month is ignored).
+// Projected values can be included to estimate future cash flows.
+func NewPriceIndex(months []time.Time, values []float64) (PriceIndex, error) {
+	if len(months) != len(values) {
+		return PriceIndex{}, errors.New("months and values must have the same length")
+	}
+	index := PriceIndex{values: make(map[time.Time]float64)}
+	for i, month := range months {
+		if values[i] <= 0 {
+			return PriceIndex{}, errors.New("index values must be positive")
+		}
+		index.values[firstOfMonth(month)] = values[i]
+	}
+	return index, nil
+}
+
+// Value returns the value of the index for the month of date.
+func (p PriceIndex) Value(date time.Time) (float64, error) {
+	value, ok := p.values[firstOfMonth(date)]
+	if !ok {
+		return 0, errors.New("missing index value for " + firstOfMonth(date).Format("2006-01"))
+	}
+	return value, nil
+}
+
+// Reference returns the reference index for date, with the three-month lag used by TIPS and index-linked gilts: the value of the third preceding month,
+// linearly interpolated towards the value of the second preceding month according to the day of the month.
+// The result isn't rounded.
+func (p PriceIndex) Reference(date time.Time) (float64, error) {
+	date = civilDate(date)
+	month := firstOfMonth(date)
+	lagged, err := p.Value(month.AddDate(0, -3, 0))
+	if err != nil {
+		return 0, err
+	}
+	next, err := p.Value(month.AddDate(0, -2, 0))
+	if err != nil {
+		return 0, err
+	}
+	return lagged + float64(date.Day()-1)/float64(daysInMonth(date.Year(), date.Month()))*(next-lagged), nil
+}
+
+// InflationLinkedBond is a bond whose principal is adjusted by the ratio between the reference index at each date and BaseIndex,
+// the reference index at the bond's dated date. The coupons are paid at the real Coupon rate on the adjusted principal.
+//
+// If DeflationFloor is true, the principal repaid at maturity is never lower than the (unadjusted) Redemption, as with TIPS.
+//
+// Prices and yields of the embedded FixedRateBond are real: Price returns the real clean price quoted in the market, and Yield the real yield.
+type InflationLinkedBond struct {
+	FixedRateBond
+	BaseIndex      float64
+	Index          PriceIndex
+	DeflationFloor bool
+}
+
+// IndexRatio returns the ratio between the reference index for date and the base index.
+func (b InflationLinkedBond) IndexRatio(date time.Time) (float64, error) {
+	if b.BaseIndex <= 0 {
+		return 0, errors.New("the base index must be positive")
+	}
+	reference, err := b.Index.Reference(date)
+	if err != nil {
+		return 0, err
+	}
+	return reference / b.BaseIndex, nil
+}
+
+// AdjustedPrincipal returns the inflation-adjusted principal at date, per 100 face value.
+func (b InflationLinkedBond) AdjustedPrincipal(date time.Time) (float64, error) {
+	ratio, err := b.IndexRatio(date)
+	if err != nil {
+		return 0, err
+	}
+	return 100 * ratio, nil
+}
+
+// CashFlows returns the dates and nominal amounts of the inflation-adjusted coupons and principal paid after settlement, per 100 face value.
+// The index must have the values needed for every payment date, projected if they're in the future.
+func (b InflationLinkedBond) CashFlows(settlement time.Time) ([]time.Time, []float64, error) {
+	dates, amounts, err := b.FixedRateBond.CashFlows(settlement)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, date := range dates {
+		ratio, err := b.IndexRatio(date)
+		if err != nil {
+			return nil, nil, err
+		}
+		amounts[i] = 100 * b.Coupon / float64(b.Frequency) * ratio
+		if i == len(dates)-1 {
+			if b.DeflationFloor {
+				ratio = math.Max(ratio, 1)
+			}
+			amounts[i] += b.Redemption * ratio
+		}
+	}
+	return dates, amounts, nil
+}
+
+// InvoicePrice returns the nominal full price (including the accrued interest) paid at settlement per 100 face value, for the given real yield.
+func (b InflationLinkedBond) InvoicePrice(settlement time.Time, realYield float64) (float64, error) {
+	ratio, err := b.IndexRatio(settlement)
+	if err != nil {
+		return 0, err
+	}
+	price, err := b.Price(settlement, realYield)
+	if err != nil {
+		return 0, err
+	}
+	accrued, err := b.AccruedInterest(settlement)
+	if err != nil {
+		return 0, err
+	}
+	return (price + accrued) * ratio, nil
+}
+
+// RealYield returns the real yield of the bond for the given nominal full price paid at settlement per 100 face value.
+func (b InflationLinkedBond) RealYield(settlement time.Time, invoicePrice float64) (float64, error) {
+	ratio, err := b.IndexRatio(settlement)
+	if err != nil {
+		return 0, err
+	}
+	accrued, err := b.AccruedInterest(settlement)
+	if err != nil {
+		return 0, err
+	}
+	return b.Yield(settlement, invoicePrice/ratio-accrued)
+}
+
+func firstOfMonth(date time.Time) time.Time {
+	y, m, _ := date.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,113 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var inflationIndex, _ = NewPriceIndex(
+	[]time.Time{date(2024, time.January, 1), date(2024, time.February, 1), date(2024, time.March, 1), date(2024, time.April, 1), date(2024, time.May, 1), date(2024, time.June, 1),
+		date(2024, time.July, 1), date(2024, time.August, 1), date(2024, time.September, 1), date(2024, time.October, 1), date(2024, time.November, 1), date(2024, time.December, 1)},
+	[]float64{308.417, 310.326, 312.332, 313.548, 314.069, 314.175, 313.534, 314.121, 314.686, 315.454, 315.493, 315.605},
+)
+
+func TestPriceIndexReference(t *testing.T) {
+	var tests = []struct {
+		date time.Time
+		want float64
+	}{
+		{date(2024, time.April, 15), 309.307867},
+		{date(2024, time.April, 1), 308.417},
+		{date(2024, time.July, 15), 313.783290},
+		// the day of the month is taken in the date's location
+		{time.Date(2024, time.April, 15, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)), 309.307867},
+	}
+
+	for _, test := range tests {
+		if got, err := inflationIndex.Reference(test.date); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("PriceIndex.Reference(%v) = %f, %v", test.date, got, err)
+		}
+	}
+
+	if _, err := inflationIndex.Reference(date(2024, time.March, 15)); err == nil {
+		t.Error("A missing index value should return an error")
+	}
+}
+
+func TestNewPriceIndex(t *testing.T) {
+	if _, err := NewPriceIndex([]time.Time{date(2024, time.January, 1)}, []float64{300, 301}); err == nil {
+		t.Error("Months and values with different lengths should return an error")
+	}
+	if _, err := NewPriceIndex([]time.Time{date(2024, time.January, 1)}, []float64{0}); err == nil {
+		t.Error("A non positive value should return an error")
+	}
+}
+
+func TestInflationLinkedBondCashFlows(t *testing.T) {
+	settlement := date(2024, time.April, 15)
+	var tests = []struct {
+		baseIndex      float64
+		deflationFloor bool
+		want           []float64
+	}{
+		{300, true, []float64{0.653715, 105.814437}},
+		// deflation: the principal is floored at par, but not the coupons
+		{320, true, []float64{0.612858, 100.616155}},
+		{320, false, []float64{0.612858, 99.201035}},
+	}
+
+	for _, test := range tests {
+		bond := InflationLinkedBond{
+			FixedRateBond:  FixedRateBond{Maturity: date(2025, time.January, 15), Coupon: 0.0125, Frequency: 2, Basis: CountActualActual, Redemption: 100},
+			BaseIndex:      test.baseIndex,
+			Index:          inflationIndex,
+			DeflationFloor: test.deflationFloor,
+		}
+		dates, amounts, err := bond.CashFlows(settlement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dates) != 2 || !dates[0].Equal(date(2024, time.July, 15)) || !dates[1].Equal(date(2025, time.January, 15)) {
+			t.Fatalf("InflationLinkedBond.CashFlows(%v) dates = %v", settlement, dates)
+		}
+		for i := range amounts {
+			if math.Abs(test.want[i]-amounts[i]) > Precision {
+				t.Errorf("InflationLinkedBond.CashFlows(%v) with base index %f and floor %t = %v", settlement, test.baseIndex, test.deflationFloor, amounts)
+			}
+		}
+	}
+
+	bond := InflationLinkedBond{FixedRateBond: FixedRateBond{Maturity: date(2025, time.July, 15), Coupon: 0.0125, Frequency: 2, Basis: CountActualActual, Redemption: 100}, BaseIndex: 300, Index: inflationIndex}
+	if _, _, err := bond.CashFlows(settlement); err == nil {
+		t.Error("A missing index value for a payment should return an error")
+	}
+}
+
+func TestInflationLinkedBondInvoicePrice(t *testing.T) {
+	bond := InflationLinkedBond{
+		FixedRateBond: FixedRateBond{Maturity: date(2034, time.January, 15), Coupon: 0.0175, Frequency: 2, Basis: CountActualActual, Redemption: 100},
+		BaseIndex:     300,
+		Index:         inflationIndex,
+	}
+	settlement := date(2024, time.April, 15)
+	realPrice, _ := bond.Price(settlement, 0.02)
+	accrued, _ := bond.AccruedInterest(settlement)
+	ratio, _ := bond.IndexRatio(settlement)
+	if math.Abs(ratio-1.031026) > Precision {
+		t.Errorf("InflationLinkedBond.IndexRatio(%v) = %f", settlement, ratio)
+	}
+	want := (realPrice + accrued) * ratio
+	invoice, err := bond.InvoicePrice(settlement, 0.02)
+	if err != nil || math.Abs(want-invoice) > Precision {
+		t.Errorf("InflationLinkedBond.InvoicePrice(%v, %f) = %f, %v, want %f", settlement, 0.02, invoice, err, want)
+	}
+	if got, err := bond.RealYield(settlement, invoice); err != nil || math.Abs(0.02-got) > Precision {
+		t.Errorf("InflationLinkedBond.RealYield(%v, %f) = %f, %v", settlement, invoice, got, err)
+	}
+
+	bond.BaseIndex = 0
+	if _, err := bond.InvoicePrice(settlement, 0.02); err == nil {
+		t.Error("A zero base index should return an error")
+	}
+}
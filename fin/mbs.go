@@ -0,0 +1,159 @@
+package fin
+
+import (
+	"errors"
+	"math"
+)
+
+// SingleMonthlyMortality returns the fraction of the outstanding balance of a mortgage pool prepaid in a month (SMM), equivalent to an annual conditional prepayment rate (CPR).
+func SingleMonthlyMortality(cpr float64) (float64, error) {
+	if cpr < 0 || cpr > 1 {
+		return 0, errors.New("the prepayment rate must be between 0 and 1")
+	}
+	return 1 - math.Pow(1-cpr, 1.0/12), nil
+}
+
+// ConditionalPrepaymentRate returns the annual conditional prepayment rate (CPR) equivalent to a single monthly mortality (SMM).
+func ConditionalPrepaymentRate(smm float64) (float64, error) {
+	if smm < 0 || smm > 1 {
+		return 0, errors.New("the prepayment rate must be between 0 and 1")
+	}
+	return 1 - math.Pow(1-smm, 12), nil
+}
+
+// PSAPrepaymentRate returns the annual conditional prepayment rate (CPR) of a mortgage pool aged the given number of months, under the PSA benchmark at the given speed.
+// At 100 PSA the CPR starts at 0.2% in the first month and increases by 0.2% per month up to 6% in month 30, remaining there afterwards.
+// Other speeds are a percentage of it, so that at 150 PSA the CPR is 1.5 times as high.
+func PSAPrepaymentRate(speed float64, month int) (float64, error) {
+	if speed < 0 {
+		return 0, errors.New("the PSA speed can't be negative")
+	}
+	if month < 1 {
+		return 0, errors.New("the month must be at least 1")
+	}
+	cpr := speed / 100 * 0.06 * math.Min(float64(month), 30) / 30
+	if cpr > 1 {
+		return 0, errors.New("the PSA speed implies a prepayment rate above 100%")
+	}
+	return cpr, nil
+}
+
+// PassThroughCashFlow is the projected cash flow of a mortgage pass-through security for one month.
+type PassThroughCashFlow struct {
+	// Month is the number of the month since the start of the projection, starting at 1
+	Month            int
+	BeginningBalance float64
+	// MortgagePayment is the scheduled payment of the mortgages, principal and gross interest
+	MortgagePayment float64
+	// NetInterest is the interest passed through to the investors, net of the servicing fee
+	NetInterest        float64
+	ScheduledPrincipal float64
+	Prepayment         float64
+	// CashFlow is the total amount passed through to the investors: net interest, scheduled principal and prepayment
+	CashFlow      float64
+	EndingBalance float64
+}
+
+// PassThroughCashFlows returns the monthly cash flows of a mortgage pass-through security, projected under the PSA prepayment benchmark at the given speed.
+//
+// balance is the outstanding balance of the pool, whose mortgages pay a weighted average coupon of mortgageRate (annual) during the remaining term (in months),
+// and have already been outstanding for age months. The investors receive the interest net of the annual servicingFee.
+func PassThroughCashFlows(balance float64, mortgageRate float64, servicingFee float64, term int, age int, speed float64) ([]PassThroughCashFlow, error) {
+	if balance <= 0 {
+		return nil, errors.New("balance must be positive")
+	}
+	if term < 1 {
+		return nil, errors.New("the remaining term must be at least one month")
+	}
+	if age < 0 {
+		return nil, errors.New("age can't be negative")
+	}
+	if servicingFee < 0 || servicingFee > mortgageRate {
+		return nil, errors.New("the servicing fee must be between zero and the mortgage rate")
+	}
+	flows := make([]PassThroughCashFlow, 0, term)
+	for month := 1; month <= term && balance > Precision; month++ {
+		payment, err := Payment(mortgageRate/12, term-month+1, balance, 0, PayEnd)
+		if err != nil {
+			return nil, err
+		}
+		cpr, err := PSAPrepaymentRate(speed, age+month)
+		if err != nil {
+			return nil, err
+		}
+		smm, err := SingleMonthlyMortality(cpr)
+		if err != nil {
+			return nil, err
+		}
+		flow := PassThroughCashFlow{
+			Month:            month,
+			BeginningBalance: balance,
+			MortgagePayment:  -payment,
+			NetInterest:      balance * (mortgageRate - servicingFee) / 12,
+		}
+		flow.ScheduledPrincipal = flow.MortgagePayment - balance*mortgageRate/12
+		flow.Prepayment = smm * (balance - flow.ScheduledPrincipal)
+		flow.CashFlow = flow.NetInterest + flow.ScheduledPrincipal + flow.Prepayment
+		flow.EndingBalance = balance - flow.ScheduledPrincipal - flow.Prepayment
+		balance = flow.EndingBalance
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
+
+// WeightedAverageLife returns the average time, in years, at which the principal of a pass-through security is repaid, weighting each month by its principal payments.
+func WeightedAverageLife(flows []PassThroughCashFlow) (float64, error) {
+	weighted, principal := 0.0, 0.0
+	for _, flow := range flows {
+		weighted += float64(flow.Month) * (flow.ScheduledPrincipal + flow.Prepayment)
+		principal += flow.ScheduledPrincipal + flow.Prepayment
+	}
+	if principal <= 0 {
+		return 0, errors.New("the cash flows don't repay any principal")
+	}
+	return weighted / principal / 12, nil
+}
+
+// PassThroughPrice returns the price, per 100 of the initial balance, of a pass-through security's projected cash flows at the given bond-equivalent yield.
+// Each month's cash flow is discounted at the monthly rate equivalent to the yield, compounded semiannually. The payment delay isn't considered.
+func PassThroughPrice(flows []PassThroughCashFlow, yield float64) (float64, error) {
+	if len(flows) == 0 {
+		return 0, errors.New("there must be at least one cash flow")
+	}
+	if yield <= -2 {
+		return 0, errors.New("yield must be greater than -200%")
+	}
+	return passThroughPrice(flows, math.Pow(1+yield/2, 1.0/6)-1), nil
+}
+
+// PassThroughYield returns the cash flow yield of a pass-through security's projected cash flows for the given price (per 100 of the initial balance),
+// expressed as a bond-equivalent yield (compounded semiannually). The payment delay isn't considered.
+func PassThroughYield(flows []PassThroughCashFlow, price float64) (float64, error) {
+	if len(flows) == 0 {
+		return 0, errors.New("there must be at least one cash flow")
+	}
+	if price <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+	function := func(rate float64) float64 {
+		return passThroughPrice(flows, rate) - price
+	}
+	a, b, err := bracket(function, 0, 0.01)
+	if err != nil {
+		return 0, err
+	}
+	monthly, err := brent(function, a, b)
+	if err != nil {
+		return 0, err
+	}
+	return 2 * (math.Pow(1+monthly, 6) - 1), nil
+}
+
+// passThroughPrice returns the price per 100 of the initial balance of the cash flows, discounted at a monthly rate
+func passThroughPrice(flows []PassThroughCashFlow, monthlyRate float64) float64 {
+	pv := 0.0
+	for _, flow := range flows {
+		pv += flow.CashFlow / math.Pow(1+monthlyRate, float64(flow.Month))
+	}
+	return 100 * pv / flows[0].BeginningBalance
+}
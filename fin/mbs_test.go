@@ -0,0 +1,132 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSingleMonthlyMortality(t *testing.T) {
+	var tests = []struct {
+		cpr  float64
+		want float64
+	}{
+		{0.06, 0.005143},
+		{0.008, 0.000669},
+		{0, 0},
+	}
+
+	for _, test := range tests {
+		if got, err := SingleMonthlyMortality(test.cpr); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("SingleMonthlyMortality(%f) = %f, %v", test.cpr, got, err)
+		}
+		if got, err := ConditionalPrepaymentRate(test.want); err != nil || math.Abs(test.cpr-got) > 1e-5 {
+			t.Errorf("ConditionalPrepaymentRate(%f) = %f, %v", test.want, got, err)
+		}
+	}
+
+	if _, err := SingleMonthlyMortality(1.5); err == nil {
+		t.Error("A rate above 1 should return an error")
+	}
+	if _, err := ConditionalPrepaymentRate(-0.1); err == nil {
+		t.Error("A negative rate should return an error")
+	}
+}
+
+func TestPSAPrepaymentRate(t *testing.T) {
+	var tests = []struct {
+		speed float64
+		month int
+		want  float64
+	}{
+		{100, 1, 0.002},
+		{100, 5, 0.01},
+		{100, 30, 0.06},
+		{100, 200, 0.06},
+		{165, 20, 0.066},
+		{50, 31, 0.03},
+	}
+
+	for _, test := range tests {
+		if got, err := PSAPrepaymentRate(test.speed, test.month); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("PSAPrepaymentRate(%f, %d) = %f, %v", test.speed, test.month, got, err)
+		}
+	}
+
+	if _, err := PSAPrepaymentRate(100, 0); err == nil {
+		t.Error("Month zero should return an error")
+	}
+}
+
+func TestPassThroughCashFlows(t *testing.T) {
+	flows, err := PassThroughCashFlows(400000000, 0.08125, 0.00625, 357, 3, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flows) != 357 {
+		t.Errorf("PassThroughCashFlows() returned %d flows", len(flows))
+	}
+	var tests = []struct {
+		got  float64
+		want float64
+	}{
+		{flows[0].MortgagePayment, 2975868.241812},
+		{flows[0].NetInterest, 2500000},
+		{flows[0].ScheduledPrincipal, 267534.908478},
+		{flows[0].Prepayment, 267470.457369},
+		{flows[0].CashFlow, 3035005.365848},
+		{flows[0].EndingBalance, 399464994.634152},
+		{flows[1].BeginningBalance, 399464994.634152},
+		{flows[len(flows)-1].EndingBalance, 0},
+	}
+	for i, test := range tests {
+		if math.Abs(test.want-test.got) > 1e-4 {
+			t.Errorf("PassThroughCashFlows() test %d = %f, want %f", i, test.got, test.want)
+		}
+	}
+
+	if _, err := PassThroughCashFlows(400000000, 0.08125, 0.09, 357, 3, 100); err == nil {
+		t.Error("A servicing fee above the mortgage rate should return an error")
+	}
+}
+
+func TestWeightedAverageLife(t *testing.T) {
+	var tests = []struct {
+		speed float64
+		want  float64
+	}{
+		{0, 20.381076},
+		{100, 11.671010},
+		{300, 5.643368},
+	}
+
+	for _, test := range tests {
+		flows, _ := PassThroughCashFlows(400000000, 0.08125, 0.00625, 357, 3, test.speed)
+		if got, err := WeightedAverageLife(flows); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("WeightedAverageLife() at %f PSA = %f, %v", test.speed, got, err)
+		}
+	}
+
+	if _, err := WeightedAverageLife(nil); err == nil {
+		t.Error("Empty cash flows should return an error")
+	}
+}
+
+func TestPassThroughYield(t *testing.T) {
+	// without servicing fee, at par the monthly yield is the mortgage rate
+	flows, _ := PassThroughCashFlows(1000000, 0.06, 0, 360, 0, 150)
+	want := 2 * (math.Pow(1.005, 6) - 1)
+	if got, err := PassThroughYield(flows, 100); err != nil || math.Abs(want-got) > Precision {
+		t.Errorf("PassThroughYield(%f) = %f, %v, want %f", 100.0, got, err, want)
+	}
+
+	flows, _ = PassThroughCashFlows(400000000, 0.08125, 0.00625, 357, 3, 165)
+	for _, price := range []float64{94, 100, 103.5} {
+		yield, err := PassThroughYield(flows, price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := PassThroughPrice(flows, yield); err != nil || math.Abs(price-got) > Precision {
+			t.Errorf("PassThroughPrice(%f) = %f, %v, want %f", yield, got, err, price)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Money is an exact amount in a currency. Arithmetic between amounts in different currencies returns an error instead of mixing them.
+// The zero value has no currency, and can't be combined with any other amount.
+type Money struct {
+	amount   Decimal
+	currency string
+}
+
+// NewMoney returns the amount in currency, an ISO 4217 code such as "USD" (see MinorUnits). The amount isn't rounded.
+func NewMoney(amount Decimal, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	if _, err := MinorUnits(currency); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// Amount returns the amount, without the currency.
+func (m Money) Amount() Decimal {
+	return m.amount
+}
+
+// Currency returns the ISO 4217 code of the currency.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Sign returns -1, 0 or 1 depending on whether the amount is negative, zero or positive.
+func (m Money) Sign() int {
+	return m.amount.Sign()
+}
+
+// Cmp returns -1, 0 or 1 depending on whether m is less than, equal to or greater than other, which must be in the same currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	return m.amount.Cmp(other.amount), nil
+}
+
+// Add returns m + other, which must be in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount.Add(other.amount), currency: m.currency}, nil
+}
+
+// Sub returns m - other, which must be in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount.Sub(other.amount), currency: m.currency}, nil
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{amount: m.amount.Neg(), currency: m.currency}
+}
+
+// Mul returns m × factor, unrounded.
+func (m Money) Mul(factor Decimal) Money {
+	return Money{amount: m.amount.Mul(factor), currency: m.currency}
+}
+
+// Round returns the amount rounded to the minor units of its currency with the rounding mode (see the Round* constants).
+func (m Money) Round(mode int) (Money, error) {
+	units, err := MinorUnits(m.currency)
+	if err != nil {
+		return Money{}, err
+	}
+	amount, err := m.amount.Round(units, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: m.currency}, nil
+}
+
+// String returns the amount followed by the currency, such as "-1234.50 EUR".
+func (m Money) String() string {
+	return m.amount.String() + " " + m.currency
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency == "" || other.currency == "" {
+		return errors.New("amount without currency")
+	}
+	if m.currency != other.currency {
+		return errors.New("currency mismatch: " + m.currency + " and " + other.currency)
+	}
+	return nil
+}
+
+// NetPresentValueMoney returns the Net Present Value of a cash flow series given a discount rate, like NetPresentValue,
+// rounded to the minor units of the currency with the rounding mode (see the Round* constants). All the values must be in the same currency.
+//
+// Excel equivalent: NPV
+func NetPresentValueMoney(rate float64, values []Money, mode int) (Money, error) {
+	amounts, currency, err := moneyAmounts(values)
+	if err != nil {
+		return Money{}, err
+	}
+	return moneyFromFloat(NetPresentValue(rate, amounts), currency, mode)
+}
+
+// InternalRateOfReturnMoney returns the internal rate of return of a cash flow series, like InternalRateOfReturn. All the values must be in the same currency.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+//
+// Excel equivalent: IRR
+func InternalRateOfReturnMoney(values []Money, guess float64) (float64, error) {
+	amounts, _, err := moneyAmounts(values)
+	if err != nil {
+		return 0, err
+	}
+	return InternalRateOfReturn(amounts, guess)
+}
+
+// ModifiedInternalRateOfReturnMoney returns the internal rate of return of a cash flow series, considering both financial and reinvestment rates,
+// like ModifiedInternalRateOfReturn. All the values must be in the same currency.
+//
+// Excel equivalent: MIRR
+func ModifiedInternalRateOfReturnMoney(values []Money, financeRate float64, reinvestRate float64) (float64, error) {
+	amounts, _, err := moneyAmounts(values)
+	if err != nil {
+		return 0, err
+	}
+	return ModifiedInternalRateOfReturn(amounts, financeRate, reinvestRate)
+}
+
+// ScheduledNetPresentValueMoney returns the Net Present Value of a scheduled cash flow series given a discount rate, like ScheduledNetPresentValue,
+// rounded to the minor units of the currency with the rounding mode (see the Round* constants). All the values must be in the same currency.
+//
+// Excel equivalent: XNPV
+func ScheduledNetPresentValueMoney(rate float64, values []Money, dates []time.Time, mode int) (Money, error) {
+	amounts, currency, err := moneyAmounts(values)
+	if err != nil {
+		return Money{}, err
+	}
+	npv, err := ScheduledNetPresentValue(rate, amounts, dates)
+	if err != nil {
+		return Money{}, err
+	}
+	return moneyFromFloat(npv, currency, mode)
+}
+
+// ScheduledInternalRateOfReturnMoney returns the internal rate of return of a scheduled cash flow series, like ScheduledInternalRateOfReturn.
+// All the values must be in the same currency.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+//
+// Excel equivalent: XIRR
+func ScheduledInternalRateOfReturnMoney(values []Money, dates []time.Time, guess float64) (float64, error) {
+	amounts, _, err := moneyAmounts(values)
+	if err != nil {
+		return 0, err
+	}
+	return ScheduledInternalRateOfReturn(amounts, dates, guess)
+}
+
+// moneyAmounts returns the amounts of the values and their common currency
+func moneyAmounts(values []Money) ([]float64, string, error) {
+	if len(values) == 0 {
+		return nil, "", errors.New("there must be at least one value")
+	}
+	amounts := make([]float64, len(values))
+	for i, value := range values {
+		if err := values[0].sameCurrency(value); err != nil {
+			return nil, "", err
+		}
+		amounts[i] = value.amount.Float64()
+	}
+	return amounts, values[0].currency, nil
+}
+
+func moneyFromFloat(x float64, currency string, mode int) (Money, error) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return Money{}, errors.New("the result isn't a finite amount")
+	}
+	amount, err := NewDecimalFromFloat(x)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: currency}.Round(mode)
+}
@@ -0,0 +1,128 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func money(amount string, currency string) Money {
+	m, err := NewMoney(decimal(amount), currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func moneySeries(currency string, amounts ...string) []Money {
+	values := make([]Money, len(amounts))
+	for i, amount := range amounts {
+		values[i] = money(amount, currency)
+	}
+	return values
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := money("10.25", "usd"), money("3.10", "USD")
+	if a.Currency() != "USD" || a.Amount().String() != "10.25" {
+		t.Errorf("NewMoney() = %s", a)
+	}
+	if got, err := a.Add(b); err != nil || got.String() != "13.35 USD" {
+		t.Errorf("Add() = %s, %v", got, err)
+	}
+	if got, err := a.Sub(b); err != nil || got.String() != "7.15 USD" {
+		t.Errorf("Sub() = %s, %v", got, err)
+	}
+	if got := a.Neg(); got.String() != "-10.25 USD" || got.Sign() != -1 {
+		t.Errorf("Neg() = %s", got)
+	}
+	if got, err := a.Mul(decimal("0.075")).Round(RoundHalfEven); err != nil || got.String() != "0.77 USD" {
+		t.Errorf("Mul().Round() = %s, %v", got, err)
+	}
+	if got, err := money("1234.5", "JPY").Round(RoundHalfEven); err != nil || got.String() != "1234 JPY" {
+		t.Errorf("Round() = %s, %v", got, err)
+	}
+	if got, err := a.Cmp(b); err != nil || got != 1 {
+		t.Errorf("Cmp() = %d, %v", got, err)
+	}
+
+	if _, err := NewMoney(decimal("1"), "XYZ"); err == nil {
+		t.Error("An unknown currency should return an error")
+	}
+	euros := money("1", "EUR")
+	if _, err := a.Add(euros); err == nil {
+		t.Error("Adding different currencies should return an error")
+	}
+	if _, err := a.Sub(euros); err == nil {
+		t.Error("Subtracting different currencies should return an error")
+	}
+	if _, err := a.Cmp(euros); err == nil {
+		t.Error("Comparing different currencies should return an error")
+	}
+	if _, err := a.Add(Money{}); err == nil {
+		t.Error("Adding an amount without currency should return an error")
+	}
+}
+
+func TestNetPresentValueMoney(t *testing.T) {
+	if got, err := NetPresentValueMoney(0.1, moneySeries("USD", "-10000", "3000", "4200", "6800"), RoundHalfEven); err != nil || got.String() != "1188.44 USD" {
+		t.Errorf("NetPresentValueMoney() = %s, %v", got, err)
+	}
+	if got, err := NetPresentValueMoney(0.1, moneySeries("KWD", "-10000", "3000", "4200", "6800"), RoundHalfEven); err != nil || got.String() != "1188.443 KWD" {
+		t.Errorf("NetPresentValueMoney() = %s, %v", got, err)
+	}
+
+	mixed := append(moneySeries("USD", "-10000", "3000"), money("4200", "EUR"))
+	if _, err := NetPresentValueMoney(0.1, mixed, RoundHalfEven); err == nil {
+		t.Error("Mixed currencies should return an error")
+	}
+	if _, err := NetPresentValueMoney(0.1, nil, RoundHalfEven); err == nil {
+		t.Error("An empty cash flow should return an error")
+	}
+}
+
+func TestInternalRateOfReturnMoney(t *testing.T) {
+	values := moneySeries("EUR", "-70000", "12000", "15000", "18000", "21000", "26000")
+	if got, err := InternalRateOfReturnMoney(values, 0.1); err != nil || math.Abs(0.086630-got) > Precision {
+		t.Errorf("InternalRateOfReturnMoney() = %f, %v", got, err)
+	}
+	values[3] = money("18000", "GBP")
+	if _, err := InternalRateOfReturnMoney(values, 0.1); err == nil {
+		t.Error("Mixed currencies should return an error")
+	}
+}
+
+func TestModifiedInternalRateOfReturnMoney(t *testing.T) {
+	values := moneySeries("EUR", "-120000", "39000", "30000", "21000", "37000", "46000")
+	if got, err := ModifiedInternalRateOfReturnMoney(values, 0.10, 0.12); err != nil || math.Abs(0.126094-got) > Precision {
+		t.Errorf("ModifiedInternalRateOfReturnMoney() = %f, %v", got, err)
+	}
+	values[0] = money("-120000", "CHF")
+	if _, err := ModifiedInternalRateOfReturnMoney(values, 0.10, 0.12); err == nil {
+		t.Error("Mixed currencies should return an error")
+	}
+}
+
+func TestScheduledMoney(t *testing.T) {
+	values := moneySeries("USD", "-10000", "2750", "4250", "3250", "2750")
+	dates := []time.Time{
+		time.Date(2008, time.Month(1), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.Month(10), 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(2), 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.Month(4), 1, 0, 0, 0, 0, time.UTC),
+	}
+	if got, err := ScheduledNetPresentValueMoney(0.09, values, dates, RoundHalfEven); err != nil || got.String() != "2086.65 USD" {
+		t.Errorf("ScheduledNetPresentValueMoney() = %s, %v", got, err)
+	}
+	if got, err := ScheduledInternalRateOfReturnMoney(values, dates, 0.1); err != nil || math.Abs(0.373363-got) > Precision {
+		t.Errorf("ScheduledInternalRateOfReturnMoney() = %f, %v", got, err)
+	}
+	if _, err := ScheduledNetPresentValueMoney(0.09, values, dates[1:], RoundHalfEven); err == nil {
+		t.Error("Values and dates of different lengths should return an error")
+	}
+	values[4] = money("2750", "EUR")
+	if _, err := ScheduledInternalRateOfReturnMoney(values, dates, 0.1); err == nil {
+		t.Error("Mixed currencies should return an error")
+	}
+}
@@ -0,0 +1,246 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// Process is a one-dimensional stochastic process that can be simulated by the MonteCarlo engine.
+type Process interface {
+	// Step returns the value of the process dt years after it had the given value, where z is a standard normal draw
+	Step(value float64, dt float64, z float64) float64
+}
+
+// GeometricBrownianMotion is the process dS = μ S dt + σ S dW followed by asset prices under the Black-Scholes model,
+// where μ is the Drift (the risk-free rate less the dividend yield, for risk-neutral pricing) and σ the Volatility, both annual.
+// It's simulated exactly, so the number of steps doesn't bias the distribution of the values.
+type GeometricBrownianMotion struct {
+	Drift      float64
+	Volatility float64
+}
+
+// Step implements the Process interface.
+func (p GeometricBrownianMotion) Step(value float64, dt float64, z float64) float64 {
+	return value * math.Exp((p.Drift-p.Volatility*p.Volatility/2)*dt+p.Volatility*math.Sqrt(dt)*z)
+}
+
+// OrnsteinUhlenbeck is the mean-reverting process dX = a (θ - X) dt + σ dW, such as the Vasicek short rate model,
+// where a is the MeanReversion speed, θ the long-term Mean and σ the (normal) Volatility.
+// It's simulated exactly, so the number of steps doesn't bias the distribution of the values.
+type OrnsteinUhlenbeck struct {
+	MeanReversion float64
+	Mean          float64
+	Volatility    float64
+}
+
+// Step implements the Process interface.
+func (p OrnsteinUhlenbeck) Step(value float64, dt float64, z float64) float64 {
+	if p.MeanReversion == 0 {
+		return value + p.Volatility*math.Sqrt(dt)*z
+	}
+	decay := math.Exp(-p.MeanReversion * dt)
+	return p.Mean + (value-p.Mean)*decay + p.Volatility*math.Sqrt((1-decay*decay)/(2*p.MeanReversion))*z
+}
+
+// Estimate is the result of a Monte Carlo simulation: the mean of the sampled payoffs and its standard error.
+type Estimate struct {
+	Value         float64
+	StandardError float64
+}
+
+// ControlVariate is a payoff whose expected value Mean is known in closed form, simulated on the same paths as the payoff being estimated
+// to reduce its variance, such as a geometric average Asian option for an arithmetic average one.
+type ControlVariate struct {
+	Payoff func(path []float64) float64
+	Mean   float64
+}
+
+// MonteCarlo is a simulation engine that estimates the expected value of a payoff over the paths of a Process.
+//
+// The paths are split into fixed chunks, each with its own random number generator seeded from Seed and the chunk number,
+// so the results are reproducible for a given Seed regardless of the number of Workers.
+type MonteCarlo struct {
+	// Paths is the number of independent samples. With Antithetic, each sample averages a pair of paths, so twice as many paths are simulated.
+	Paths int
+	// Steps is the number of time steps of each path
+	Steps int
+	Seed  int64
+	// Antithetic pairs each path with its reflection, simulated with the negated normal draws
+	Antithetic bool
+	// Workers is the number of goroutines running the simulation. If zero, GOMAXPROCS is used.
+	Workers int
+}
+
+// monteCarloChunk is the number of samples drawn from each random number generator
+const monteCarloChunk = 1000
+
+// Simulate returns the estimated expected value of payoff over paths of the process starting at initial and ending years later.
+// Each path passed to payoff holds the Steps+1 values of the process, evenly spaced from initial (at index 0) to the value at the end.
+// The payoff must not keep nor modify the path, and must be safe for concurrent use. Discounting, if any, is up to the payoff.
+func (m MonteCarlo) Simulate(process Process, initial float64, years float64, payoff func(path []float64) float64) (Estimate, error) {
+	moments, err := m.run(process, initial, years, payoff, nil)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return Estimate{Value: moments.meanY, StandardError: standardError(moments.m2Y/moments.n, m.Paths)}, nil
+}
+
+// SimulateControlled returns the estimated expected value of payoff like Simulate, reducing its variance with the control variate.
+// The payoff is adjusted by the deviation of the control from its known mean, times the coefficient that minimizes the variance,
+// estimated from the same samples.
+func (m MonteCarlo) SimulateControlled(process Process, initial float64, years float64, payoff func(path []float64) float64, control ControlVariate) (Estimate, error) {
+	if control.Payoff == nil {
+		return Estimate{}, errors.New("the control variate needs a payoff")
+	}
+	moments, err := m.run(process, initial, years, payoff, control.Payoff)
+	if err != nil {
+		return Estimate{}, err
+	}
+	varianceY, varianceC, covariance := moments.m2Y/moments.n, moments.m2C/moments.n, moments.coYC/moments.n
+	if varianceC <= 0 {
+		return Estimate{Value: moments.meanY, StandardError: standardError(varianceY, m.Paths)}, nil
+	}
+	beta := covariance / varianceC
+	return Estimate{
+		Value:         moments.meanY - beta*(moments.meanC-control.Mean),
+		StandardError: standardError(varianceY-beta*covariance, m.Paths),
+	}, nil
+}
+
+// monteCarloMoments are the number of samples, the means of the payoffs y and the controls c, the sums of their squared deviations
+// from the means and the sum of the products of their deviations. Accumulating deviations instead of raw sums keeps the precision
+// of the variance when the mean is large relative to the spread.
+type monteCarloMoments struct {
+	n, meanY, meanC, m2Y, m2C, coYC float64
+}
+
+// add updates the moments with a sample, using Welford's algorithm
+func (s *monteCarloMoments) add(y float64, c float64) {
+	s.n++
+	dy, dc := y-s.meanY, c-s.meanC
+	s.meanY += dy / s.n
+	s.meanC += dc / s.n
+	s.m2Y += dy * (y - s.meanY)
+	s.m2C += dc * (c - s.meanC)
+	s.coYC += dy * (c - s.meanC)
+}
+
+// merge combines the moments with those of other samples, using Chan's parallel algorithm
+func (s *monteCarloMoments) merge(other monteCarloMoments) {
+	if other.n == 0 {
+		return
+	}
+	n := s.n + other.n
+	dy, dc := other.meanY-s.meanY, other.meanC-s.meanC
+	weight := s.n * other.n / n
+	s.meanY += dy * other.n / n
+	s.meanC += dc * other.n / n
+	s.m2Y += other.m2Y + dy*dy*weight
+	s.m2C += other.m2C + dc*dc*weight
+	s.coYC += other.coYC + dy*dc*weight
+	s.n = n
+}
+
+// run simulates the samples in chunks across the workers, and merges the moments of the chunks in order so that the result doesn't depend on the scheduling
+func (m MonteCarlo) run(process Process, initial float64, years float64, payoff func([]float64) float64, control func([]float64) float64) (monteCarloMoments, error) {
+	if process == nil || payoff == nil {
+		return monteCarloMoments{}, errors.New("a process and a payoff are required")
+	}
+	if m.Paths < 2 {
+		return monteCarloMoments{}, errors.New("there must be at least two paths")
+	}
+	if m.Steps < 1 {
+		return monteCarloMoments{}, errors.New("there must be at least one step")
+	}
+	if years <= 0 {
+		return monteCarloMoments{}, errors.New("years must be positive")
+	}
+	if m.Workers < 0 {
+		return monteCarloMoments{}, errors.New("workers can't be negative")
+	}
+	workers := m.Workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunks := make([]monteCarloMoments, (m.Paths+monteCarloChunk-1)/monteCarloChunk)
+	if workers > len(chunks) {
+		workers = len(chunks)
+	}
+
+	next := make(chan int, len(chunks))
+	for i := range chunks {
+		next <- i
+	}
+	close(next)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			path, reflected := make([]float64, m.Steps+1), make([]float64, m.Steps+1)
+			for i := range next {
+				samples := monteCarloChunk
+				if last := m.Paths - i*monteCarloChunk; last < samples {
+					samples = last
+				}
+				chunks[i] = m.chunk(process, initial, years, payoff, control, rand.New(rand.NewSource(chunkSeed(m.Seed, i))), samples, path, reflected)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var moments monteCarloMoments
+	for _, chunk := range chunks {
+		moments.merge(chunk)
+	}
+	return moments, nil
+}
+
+// chunk simulates the given number of samples with the random number generator, using path and reflected as buffers
+func (m MonteCarlo) chunk(process Process, initial float64, years float64, payoff func([]float64) float64, control func([]float64) float64,
+	random *rand.Rand, samples int, path []float64, reflected []float64) monteCarloMoments {
+	var moments monteCarloMoments
+	dt := years / float64(m.Steps)
+	for k := 0; k < samples; k++ {
+		path[0], reflected[0] = initial, initial
+		for i := 1; i <= m.Steps; i++ {
+			z := random.NormFloat64()
+			path[i] = process.Step(path[i-1], dt, z)
+			if m.Antithetic {
+				reflected[i] = process.Step(reflected[i-1], dt, -z)
+			}
+		}
+		y, c := payoff(path), 0.0
+		if control != nil {
+			c = control(path)
+		}
+		if m.Antithetic {
+			y = (y + payoff(reflected)) / 2
+			if control != nil {
+				c = (c + control(reflected)) / 2
+			}
+		}
+		moments.add(y, c)
+	}
+	return moments
+}
+
+// chunkSeed derives the seed of a chunk's random number generator with the SplitMix64 mixing function,
+// so that the streams of consecutive chunks aren't correlated
+func chunkSeed(seed int64, chunk int) int64 {
+	z := uint64(seed) + uint64(chunk+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
+
+func standardError(variance float64, samples int) float64 {
+	if variance <= 0 {
+		return 0
+	}
+	// sample variance, with Bessel's correction
+	return math.Sqrt(variance * float64(samples) / float64(samples-1) / float64(samples))
+}
@@ -0,0 +1,189 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+// geometricAsianCall returns the price of a call on the geometric average of the prices at the end of each of n equal periods, under Black-Scholes
+func geometricAsianCall(spot float64, strike float64, years float64, rate float64, volatility float64, n int) float64 {
+	dt := years / float64(n)
+	mean := math.Log(spot) + (rate-volatility*volatility/2)*dt*float64(n+1)/2
+	variance := volatility * volatility * dt * float64((n+1)*(2*n+1)) / float64(6*n)
+	d1 := (mean - math.Log(strike) + variance) / math.Sqrt(variance)
+	d2 := d1 - math.Sqrt(variance)
+	return math.Exp(-rate*years) * (math.Exp(mean+variance/2)*normalDistribution(d1) - strike*normalDistribution(d2))
+}
+
+func asianPayoffs(strike float64, years float64, rate float64) (func([]float64) float64, func([]float64) float64) {
+	discount := math.Exp(-rate * years)
+	arithmetic := func(path []float64) float64 {
+		sum := 0.0
+		for _, price := range path[1:] {
+			sum += price
+		}
+		return discount * math.Max(sum/float64(len(path)-1)-strike, 0)
+	}
+	geometric := func(path []float64) float64 {
+		sum := 0.0
+		for _, price := range path[1:] {
+			sum += math.Log(price)
+		}
+		return discount * math.Max(math.Exp(sum/float64(len(path)-1))-strike, 0)
+	}
+	return arithmetic, geometric
+}
+
+func TestMonteCarloEuropean(t *testing.T) {
+	process := GeometricBrownianMotion{Drift: 0.1, Volatility: 0.2}
+	call := func(path []float64) float64 {
+		return math.Exp(-0.1*0.5) * math.Max(path[len(path)-1]-40, 0)
+	}
+	want, _ := BlackScholes(OptionCall, 42, 40, 0.5, 0.1, 0, 0.2)
+
+	plain, err := MonteCarlo{Paths: 50000, Steps: 1, Seed: 1}.Simulate(process, 42, 0.5, call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(plain.Value-want.Price) > 3*plain.StandardError {
+		t.Errorf("Simulate() = %f ± %f, want %f", plain.Value, plain.StandardError, want.Price)
+	}
+	antithetic, err := MonteCarlo{Paths: 50000, Steps: 1, Seed: 1, Antithetic: true}.Simulate(process, 42, 0.5, call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(antithetic.Value-want.Price) > 3*antithetic.StandardError {
+		t.Errorf("Simulate() with antithetic variates = %f ± %f, want %f", antithetic.Value, antithetic.StandardError, want.Price)
+	}
+	if antithetic.StandardError >= plain.StandardError {
+		t.Errorf("Antithetic variates didn't reduce the standard error: %f >= %f", antithetic.StandardError, plain.StandardError)
+	}
+}
+
+func TestMonteCarloDeterministic(t *testing.T) {
+	process := GeometricBrownianMotion{Drift: 0.05, Volatility: 0.3}
+	arithmetic, _ := asianPayoffs(100, 1, 0.05)
+	var want Estimate
+	for i, workers := range []int{1, 2, 3, 8, 0} {
+		got, err := MonteCarlo{Paths: 10500, Steps: 12, Seed: 42, Antithetic: true, Workers: workers}.Simulate(process, 100, 1, arithmetic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			want = got
+		} else if got != want {
+			t.Errorf("Simulate() with %d workers = %v, want %v", workers, got, want)
+		}
+	}
+	if other, _ := (MonteCarlo{Paths: 10500, Steps: 12, Seed: 43}).Simulate(process, 100, 1, arithmetic); other == want {
+		t.Error("Different seeds should give different estimates")
+	}
+}
+
+func TestMonteCarloControlVariate(t *testing.T) {
+	process := GeometricBrownianMotion{Drift: 0.05, Volatility: 0.3}
+	arithmetic, geometric := asianPayoffs(100, 1, 0.05)
+	control := ControlVariate{Payoff: geometric, Mean: geometricAsianCall(100, 100, 1, 0.05, 0.3, 12)}
+	engine := MonteCarlo{Paths: 20000, Steps: 12, Seed: 7}
+
+	// the control variate estimates itself exactly
+	if got, err := engine.SimulateControlled(process, 100, 1, geometric, control); err != nil || math.Abs(got.Value-control.Mean) > Precision || got.StandardError > Precision {
+		t.Errorf("SimulateControlled() of the control = %v, %v, want %f", got, err, control.Mean)
+	}
+	// the geometric average is close to the closed form
+	if got, _ := engine.Simulate(process, 100, 1, geometric); math.Abs(got.Value-control.Mean) > 3*got.StandardError {
+		t.Errorf("Simulate() of the geometric average = %f ± %f, want %f", got.Value, got.StandardError, control.Mean)
+	}
+
+	plain, _ := engine.Simulate(process, 100, 1, arithmetic)
+	controlled, err := engine.SimulateControlled(process, 100, 1, arithmetic, control)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if controlled.StandardError > plain.StandardError/10 {
+		t.Errorf("The control variate didn't reduce the standard error enough: %f, %f", controlled.StandardError, plain.StandardError)
+	}
+	if math.Abs(controlled.Value-plain.Value) > 3*plain.StandardError || controlled.Value <= control.Mean {
+		t.Errorf("SimulateControlled() = %f, Simulate() = %f ± %f", controlled.Value, plain.Value, plain.StandardError)
+	}
+}
+
+func TestMonteCarloLargeMean(t *testing.T) {
+	process := GeometricBrownianMotion{Drift: 0.05, Volatility: 0.2}
+	engine := MonteCarlo{Paths: 100000, Steps: 1, Seed: 11}
+	last := func(path []float64) float64 {
+		return path[len(path)-1]
+	}
+	shifted := func(path []float64) float64 {
+		return 1e9 + path[len(path)-1]
+	}
+
+	// adding a constant to the payoff shifts the value but leaves the standard error unchanged
+	plain, err := engine.Simulate(process, 100, 1, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := engine.Simulate(process, 100, 1, shifted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.Value-1e9-plain.Value) > Precision || math.Abs(got.StandardError-plain.StandardError) > Precision {
+		t.Errorf("Simulate() with a large constant = %f ± %f, want %f ± %f", got.Value-1e9, got.StandardError, plain.Value, plain.StandardError)
+	}
+
+	// and so does adding it to the control
+	control := ControlVariate{Payoff: last, Mean: 100 * math.Exp(0.05)}
+	shiftedControl := ControlVariate{Payoff: shifted, Mean: 1e9 + control.Mean}
+	call := func(path []float64) float64 {
+		return 1e9 + math.Max(path[len(path)-1]-100, 0)
+	}
+	want, err := engine.SimulateControlled(process, 100, 1, call, control)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = engine.SimulateControlled(process, 100, 1, call, shiftedControl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want.StandardError == 0 || math.Abs(got.Value-want.Value) > Precision || math.Abs(got.StandardError-want.StandardError) > Precision {
+		t.Errorf("SimulateControlled() with a large constant = %f ± %f, want %f ± %f", got.Value-1e9, got.StandardError, want.Value-1e9, want.StandardError)
+	}
+}
+
+func TestMonteCarloOrnsteinUhlenbeck(t *testing.T) {
+	process := OrnsteinUhlenbeck{MeanReversion: 0.5, Mean: 0.04, Volatility: 0.01}
+	last := func(path []float64) float64 {
+		return path[len(path)-1]
+	}
+	want := 0.04 + (0.02-0.04)*math.Exp(-0.5*3)
+	got, err := MonteCarlo{Paths: 20000, Steps: 36, Seed: 3}.Simulate(process, 0.02, 3, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.Value-want) > 3*got.StandardError {
+		t.Errorf("Simulate() = %f ± %f, want %f", got.Value, got.StandardError, want)
+	}
+	// the standard deviation of the value is σ √((1 - e^(-2 a T)) / (2 a))
+	stdDev := 0.01 * math.Sqrt((1-math.Exp(-2*0.5*3))/(2*0.5))
+	if math.Abs(got.StandardError*math.Sqrt(20000)-stdDev) > 0.05*stdDev {
+		t.Errorf("Simulate() standard deviation = %f, want %f", got.StandardError*math.Sqrt(20000), stdDev)
+	}
+}
+
+func TestMonteCarloErrors(t *testing.T) {
+	process := GeometricBrownianMotion{Drift: 0.05, Volatility: 0.2}
+	last := func(path []float64) float64 {
+		return path[len(path)-1]
+	}
+	for _, engine := range []MonteCarlo{{Paths: 1, Steps: 1}, {Paths: 100, Steps: 0}, {Paths: 100, Steps: 1, Workers: -1}} {
+		if _, err := engine.Simulate(process, 100, 1, last); err == nil {
+			t.Errorf("Simulate() with %v should return an error", engine)
+		}
+	}
+	if _, err := (MonteCarlo{Paths: 100, Steps: 1}).Simulate(process, 100, 0, last); err == nil {
+		t.Error("A zero horizon should return an error")
+	}
+	if _, err := (MonteCarlo{Paths: 100, Steps: 1}).SimulateControlled(process, 100, 1, last, ControlVariate{}); err == nil {
+		t.Error("A control variate without a payoff should return an error")
+	}
+}
@@ -0,0 +1,292 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// In the odd coupon functions, an odd period that's longer than a regular coupon period (long stub) is split into quasi-coupon periods,
+// which are the regular periods the bond would have had if it had been issued earlier (odd first period) or matured later (odd last period).
+// The accrued interest and the odd coupon are the sum of the fractions of each quasi-coupon period covered by the odd period.
+
+// OddFirstPrice returns the price per $100 face value of a bond having an odd (short or long) first period
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// issue is the unix timestamp (seconds) for the issue date
+//
+// firstCoupon is the unix timestamp (seconds) for the first coupon date, which must fall on the coupon schedule rolled backwards from maturity
+//
+// rate is the bond's annual coupon rate
+//
+// yield is the bond's annual yield
+//
+// redemption is the bond's redemption value per $100 face value
+//
+// frequency is the number of coupon payments per year
+//
+// Excel equivalent: ODDFPRICE
+func OddFirstPrice(settlement int64, maturity int64, issue int64, firstCoupon int64, rate float64, yield float64, redemption float64, frequency int, basis int) (float64, error) {
+	terms, err := oddFirstTerms(settlement, maturity, issue, firstCoupon, frequency, basis)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateOddCoupon(rate, redemption); err != nil {
+		return 0, err
+	}
+	return terms.price(rate, yield, redemption, frequency), nil
+}
+
+// OddFirstYield returns the annual yield of a bond having an odd (short or long) first period
+//
+// settlement is the unix timestamp (seconds) for the settlement date
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// issue is the unix timestamp (seconds) for the issue date
+//
+// firstCoupon is the unix timestamp (seconds) for the first coupon date, which must fall on the coupon schedule rolled backwards from maturity
+//
+// rate is the bond's annual coupon rate
+//
+// price is the bond's price per $100 face value
+//
+// redemption is the bond's redemption value per $100 face value
+//
+// frequency is the number of coupon payments per year
+//
+// Excel equivalent: ODDFYIELD
+func OddFirstYield(settlement int64, maturity int64, issue int64, firstCoupon int64, rate float64, price float64, redemption float64, frequency int, basis int) (float64, error) {
+	terms, err := oddFirstTerms(settlement, maturity, issue, firstCoupon, frequency, basis)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateOddCoupon(rate, redemption); err != nil {
+		return 0, err
+	}
+	if price <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+	function := func(yield float64) float64 {
+		return terms.price(rate, yield, redemption, frequency) - price
+	}
+	a, b, err := bracket(function, 0, 0.1)
+	if err != nil {
+		return 0, err
+	}
+	return brent(function, a, b)
+}
+
+// OddLastPrice returns the price per $100 face value of a bond having an odd (short or long) last period
+//
+// settlement is the unix timestamp (seconds) for the settlement date, which must fall in the last period
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// lastInterest is the unix timestamp (seconds) for the last coupon date before maturity
+//
+// rate is the bond's annual coupon rate
+//
+// yield is the bond's annual yield
+//
+// redemption is the bond's redemption value per $100 face value
+//
+// frequency is the number of coupon payments per year
+//
+// Excel equivalent: ODDLPRICE
+func OddLastPrice(settlement int64, maturity int64, lastInterest int64, rate float64, yield float64, redemption float64, frequency int, basis int) (float64, error) {
+	terms, err := oddLastTerms(settlement, maturity, lastInterest, frequency, basis)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateOddCoupon(rate, redemption); err != nil {
+		return 0, err
+	}
+	coupon := 100 * rate / float64(frequency)
+	return (redemption+terms.stub*coupon)/(1+terms.remaining*yield/float64(frequency)) - terms.accrued*coupon, nil
+}
+
+// OddLastYield returns the annual yield of a bond having an odd (short or long) last period
+//
+// settlement is the unix timestamp (seconds) for the settlement date, which must fall in the last period
+//
+// maturity is the unix timestamp (seconds) for the maturity date
+//
+// lastInterest is the unix timestamp (seconds) for the last coupon date before maturity
+//
+// rate is the bond's annual coupon rate
+//
+// price is the bond's price per $100 face value
+//
+// redemption is the bond's redemption value per $100 face value
+//
+// frequency is the number of coupon payments per year
+//
+// Excel equivalent: ODDLYIELD
+func OddLastYield(settlement int64, maturity int64, lastInterest int64, rate float64, price float64, redemption float64, frequency int, basis int) (float64, error) {
+	terms, err := oddLastTerms(settlement, maturity, lastInterest, frequency, basis)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateOddCoupon(rate, redemption); err != nil {
+		return 0, err
+	}
+	if price <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+	coupon := 100 * rate / float64(frequency)
+	dirty := price + terms.accrued*coupon
+	return (redemption + terms.stub*coupon - dirty) / dirty * float64(frequency) / terms.remaining, nil
+}
+
+// oddFirstPeriod holds the schedule-dependent terms of the odd first period formula
+type oddFirstPeriod struct {
+	// coupons is the number of regular coupons after the first one
+	coupons int
+	// stub is the first coupon as a fraction of a regular one
+	stub float64
+	// accrued is the accrued interest at settlement as a fraction of a regular coupon
+	accrued float64
+	// periods is the number of quasi-coupon periods between settlement and the first coupon
+	periods float64
+}
+
+func (p oddFirstPeriod) price(rate float64, yield float64, redemption float64, frequency int) float64 {
+	coupon := 100 * rate / float64(frequency)
+	v := 1 + yield/float64(frequency)
+	price := redemption/math.Pow(v, float64(p.coupons)+p.periods) + coupon*p.stub/math.Pow(v, p.periods)
+	for k := 1; k <= p.coupons; k++ {
+		price += coupon / math.Pow(v, float64(k)+p.periods)
+	}
+	return price - coupon*p.accrued
+}
+
+func oddFirstTerms(settlement int64, maturity int64, issue int64, firstCoupon int64, frequency int, basis int) (oddFirstPeriod, error) {
+	if !isValidFrequency(frequency) {
+		return oddFirstPeriod{}, errors.New("frequency must be 1, 2, 3, 4, 6 or 12")
+	}
+	if !isValidBasis(basis) {
+		return oddFirstPeriod{}, errors.New("invalid day count basis")
+	}
+	settlementDate, maturityDate := civilDate(time.Unix(settlement, 0).UTC()), civilDate(time.Unix(maturity, 0).UTC())
+	issueDate, firstCouponDate := civilDate(time.Unix(issue, 0).UTC()), civilDate(time.Unix(firstCoupon, 0).UTC())
+	if !issueDate.Before(settlementDate) || !settlementDate.Before(firstCouponDate) || !firstCouponDate.Before(maturityDate) {
+		return oddFirstPeriod{}, errors.New("dates must satisfy issue < settlement < firstCoupon < maturity")
+	}
+
+	months := 12 / frequency
+	endOfMonth := isEndOfMonth(maturityDate)
+	k := 0
+	for addMonths(maturityDate, -k*months, endOfMonth).After(firstCouponDate) {
+		k++
+	}
+	if !addMonths(maturityDate, -k*months, endOfMonth).Equal(firstCouponDate) {
+		return oddFirstPeriod{}, errors.New("the first coupon date must fall on the coupon schedule of the maturity date")
+	}
+
+	// quasi-coupon periods of the first period, from the first coupon backwards
+	terms := oddFirstPeriod{coupons: k}
+	end := firstCouponDate
+	for end.After(issueDate) {
+		k++
+		start := addMonths(maturityDate, -k*months, endOfMonth)
+		length := quasiCouponDays(start, end, frequency, basis)
+		terms.stub += float64(oddDays(maxDate(start, issueDate), end, basis)) / length
+		if settlementDate.After(maxDate(start, issueDate)) {
+			terms.accrued += float64(oddDays(maxDate(start, issueDate), minDate(settlementDate, end), basis)) / length
+		}
+		if !settlementDate.Before(start) && settlementDate.Before(end) {
+			terms.periods += float64(oddDays(settlementDate, end, basis)) / length
+		} else if settlementDate.Before(start) {
+			terms.periods++
+		}
+		end = start
+	}
+	return terms, nil
+}
+
+// oddLastPeriod holds the schedule-dependent terms of the odd last period formula, as fractions of a regular coupon period
+type oddLastPeriod struct {
+	// stub is the length of the last period
+	stub float64
+	// accrued is the time from the last coupon to settlement
+	accrued float64
+	// remaining is the time from settlement to maturity
+	remaining float64
+}
+
+func oddLastTerms(settlement int64, maturity int64, lastInterest int64, frequency int, basis int) (oddLastPeriod, error) {
+	if !isValidFrequency(frequency) {
+		return oddLastPeriod{}, errors.New("frequency must be 1, 2, 3, 4, 6 or 12")
+	}
+	if !isValidBasis(basis) {
+		return oddLastPeriod{}, errors.New("invalid day count basis")
+	}
+	settlementDate, maturityDate := civilDate(time.Unix(settlement, 0).UTC()), civilDate(time.Unix(maturity, 0).UTC())
+	lastInterestDate := civilDate(time.Unix(lastInterest, 0).UTC())
+	if !lastInterestDate.Before(settlementDate) || !settlementDate.Before(maturityDate) {
+		return oddLastPeriod{}, errors.New("dates must satisfy lastInterest < settlement < maturity")
+	}
+
+	// quasi-coupon periods of the last period, from the last coupon forwards
+	var terms oddLastPeriod
+	months := 12 / frequency
+	endOfMonth := isEndOfMonth(lastInterestDate)
+	start := lastInterestDate
+	for k := 1; start.Before(maturityDate); k++ {
+		end := addMonths(lastInterestDate, k*months, endOfMonth)
+		length := quasiCouponDays(start, end, frequency, basis)
+		terms.stub += float64(oddDays(start, minDate(maturityDate, end), basis)) / length
+		if settlementDate.After(start) {
+			terms.accrued += float64(oddDays(start, minDate(settlementDate, end), basis)) / length
+		}
+		if maturityDate.After(maxDate(settlementDate, start)) {
+			terms.remaining += float64(oddDays(maxDate(settlementDate, start), minDate(maturityDate, end), basis)) / length
+		}
+		start = end
+	}
+	return terms, nil
+}
+
+// quasiCouponDays returns the number of days of a regular coupon period: the actual days for the actual/actual basis,
+// or the days of the basis' year divided by the frequency otherwise
+func quasiCouponDays(start time.Time, end time.Time, frequency int, basis int) float64 {
+	switch basis {
+	case CountActualActual:
+		return float64(actualDays(start, end))
+	case CountActual365:
+		return 365 / float64(frequency)
+	}
+	return 360 / float64(frequency)
+}
+
+func oddDays(from time.Time, to time.Time, basis int) int {
+	return DaysDifference(from.Unix(), to.Unix(), basis)
+}
+
+func validateOddCoupon(rate float64, redemption float64) error {
+	if rate < 0 {
+		return errors.New("rate can't be negative")
+	}
+	if redemption <= 0 {
+		return errors.New("redemption must be positive")
+	}
+	return nil
+}
+
+func minDate(date1 time.Time, date2 time.Time) time.Time {
+	if date1.Before(date2) {
+		return date1
+	}
+	return date2
+}
+
+func maxDate(date1 time.Time, date2 time.Time) time.Time {
+	if date1.After(date2) {
+		return date1
+	}
+	return date2
+}
@@ -0,0 +1,115 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOddFirstPrice(t *testing.T) {
+	settlement := time.Date(2008, time.November, 11, 0, 0, 0, 0, time.UTC).Unix()
+	maturity := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC).Unix()
+	var tests = []struct {
+		issue       int64
+		firstCoupon int64
+		basis       int
+		want        float64
+	}{
+		// short first period: the example of Excel's ODDFPRICE documentation
+		{time.Date(2008, time.October, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), CountActualActual, 113.597717},
+		// regular first period
+		{time.Date(2008, time.September, 1, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), CountActualActual, 113.580040},
+		// long first period from 2008-01-20 to 2009-03-01, across three quasi-coupon periods starting 2007-09-01.
+		// Expected values from the long first coupon formula of Excel's ODDFPRICE documentation, evaluated separately
+		{time.Date(2008, time.January, 20, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), CountActualActual, 113.490939},
+		{time.Date(2008, time.January, 20, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), CountNasd, 113.491558},
+	}
+
+	for _, test := range tests {
+		if got, err := OddFirstPrice(settlement, maturity, test.issue, test.firstCoupon, 0.0785, 0.0625, 100, 2, test.basis); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("OddFirstPrice(%d, %d, %d, %d, %f, %f, %f, %d, %d) = %f, %v", settlement, maturity, test.issue, test.firstCoupon, 0.0785, 0.0625, 100.0, 2, test.basis, got, err)
+		}
+	}
+
+	issue := time.Date(2008, time.October, 15, 0, 0, 0, 0, time.UTC).Unix()
+	if _, err := OddFirstPrice(settlement, maturity, issue, time.Date(2009, time.February, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0785, 0.0625, 100, 2, CountNasd); err == nil {
+		t.Error("A first coupon off the maturity's schedule should return an error")
+	}
+	if _, err := OddFirstPrice(issue, maturity, settlement, time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC).Unix(), 0.0785, 0.0625, 100, 2, CountNasd); err == nil {
+		t.Error("A settlement before the issue date should return an error")
+	}
+}
+
+func TestOddFirstYield(t *testing.T) {
+	settlement := time.Date(2008, time.November, 11, 0, 0, 0, 0, time.UTC).Unix()
+	maturity := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC).Unix()
+	issue := time.Date(2008, time.October, 15, 0, 0, 0, 0, time.UTC).Unix()
+	firstCoupon := time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC).Unix()
+	if got, err := OddFirstYield(settlement, maturity, issue, firstCoupon, 0.0575, 84.50, 100, 2, CountNasd); err != nil || math.Abs(0.077246-got) > Precision {
+		t.Errorf("OddFirstYield(%d, %d, %d, %d, %f, %f, %f, %d, %d) = %f, %v", settlement, maturity, issue, firstCoupon, 0.0575, 84.50, 100.0, 2, CountNasd, got, err)
+	}
+
+	// long first period, with the price of TestOddFirstPrice
+	issue = time.Date(2008, time.January, 20, 0, 0, 0, 0, time.UTC).Unix()
+	if got, err := OddFirstYield(settlement, maturity, issue, firstCoupon, 0.0785, 113.490939, 100, 2, CountActualActual); err != nil || math.Abs(0.0625-got) > Precision {
+		t.Errorf("OddFirstYield(%d, %d, %d, %d, %f, %f, %f, %d, %d) = %f, %v", settlement, maturity, issue, firstCoupon, 0.0785, 113.490939, 100.0, 2, CountActualActual, got, err)
+	}
+
+	// long first period: the yield prices the bond back
+	for _, basis := range []int{CountNasd, CountActualActual, CountActual360, CountActual365, CountEuropean} {
+		price, _ := OddFirstPrice(settlement, maturity, issue, firstCoupon, 0.0575, 0.065, 100, 2, basis)
+		if got, err := OddFirstYield(settlement, maturity, issue, firstCoupon, 0.0575, price, 100, 2, basis); err != nil || math.Abs(0.065-got) > Precision {
+			t.Errorf("OddFirstYield(%d, %d, %d, %d, %f, %f, %f, %d, %d) = %f, %v", settlement, maturity, issue, firstCoupon, 0.0575, price, 100.0, 2, basis, got, err)
+		}
+	}
+}
+
+func TestOddLastPrice(t *testing.T) {
+	var tests = []struct {
+		settlement   int64
+		maturity     int64
+		lastInterest int64
+		rate         float64
+		yield        float64
+		basis        int
+		want         float64
+	}{
+		// long last period (from 2007-10-15 to 2008-06-15): the example of Excel's ODDLPRICE documentation
+		{time.Date(2008, time.February, 7, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.June, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2007, time.October, 15, 0, 0, 0, 0, time.UTC).Unix(), 0.0375, 0.0405, CountNasd, 99.878286},
+		// short last period: the price of the example of Excel's ODDLYIELD documentation
+		{time.Date(2008, time.April, 20, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.June, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2007, time.December, 24, 0, 0, 0, 0, time.UTC).Unix(), 0.0375, 0.045192235629, CountNasd, 99.875000},
+	}
+
+	for _, test := range tests {
+		if got, err := OddLastPrice(test.settlement, test.maturity, test.lastInterest, test.rate, test.yield, 100, 2, test.basis); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("OddLastPrice(%d, %d, %d, %f, %f, %f, %d, %d) = %f, %v", test.settlement, test.maturity, test.lastInterest, test.rate, test.yield, 100.0, 2, test.basis, got, err)
+		}
+	}
+
+	if _, err := OddLastPrice(tests[0].lastInterest, tests[0].maturity, tests[0].settlement, 0.0375, 0.0405, 100, 2, CountNasd); err == nil {
+		t.Error("A settlement before the last coupon should return an error")
+	}
+}
+
+func TestOddLastYield(t *testing.T) {
+	var tests = []struct {
+		settlement   int64
+		maturity     int64
+		lastInterest int64
+		rate         float64
+		price        float64
+		basis        int
+		want         float64
+	}{
+		// short last period: the price of the example of Excel's ODDLYIELD documentation
+		{time.Date(2008, time.April, 20, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.June, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2007, time.December, 24, 0, 0, 0, 0, time.UTC).Unix(), 0.0375, 99.875, CountNasd, 0.045192},
+		// long last period (from 2007-10-15 to 2008-06-15): the example of Excel's ODDLPRICE documentation
+		{time.Date(2008, time.February, 7, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2008, time.June, 15, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2007, time.October, 15, 0, 0, 0, 0, time.UTC).Unix(), 0.0375, 99.878286, CountNasd, 0.040500},
+	}
+
+	for _, test := range tests {
+		if got, err := OddLastYield(test.settlement, test.maturity, test.lastInterest, test.rate, test.price, 100, 2, test.basis); err != nil || math.Abs(test.want-got) > Precision {
+			t.Errorf("OddLastYield(%d, %d, %d, %f, %f, %f, %d, %d) = %f, %v", test.settlement, test.maturity, test.lastInterest, test.rate, test.price, 100.0, 2, test.basis, got, err)
+		}
+	}
+}
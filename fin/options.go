@@ -0,0 +1,166 @@
+package fin
+
+import (
+	"errors"
+	"math"
+)
+
+// These constants are used in the option pricing functions (parameter "optionType"):
+const (
+	OptionCall = iota
+	OptionPut
+)
+
+// Greeks holds the price of an option along with its sensitivities to the pricing parameters.
+type Greeks struct {
+	Price float64
+	// Delta is the change in price per unit change in the spot price
+	Delta float64
+	// Gamma is the change in delta per unit change in the spot price
+	Gamma float64
+	// Vega is the change in price per unit change in the volatility (multiply by 0.01 for a one point change)
+	Vega float64
+	// Theta is the change in price per year elapsed (divide by 365 for a one day change)
+	Theta float64
+	// Rho is the change in price per unit change in the (domestic) risk-free rate
+	Rho float64
+}
+
+// BlackScholes returns the price and Greeks of a European option on an asset paying a continuous dividend yield, under the Black-Scholes-Merton model.
+//
+// spot and strike are the prices of the underlying asset and the strike price, and years is the time to expiry.
+// rate, dividendYield and volatility are annual and continuously compounded.
+func BlackScholes(optionType int, spot float64, strike float64, years float64, rate float64, dividendYield float64, volatility float64) (Greeks, error) {
+	if err := validateOption(optionType, spot, strike, years, volatility); err != nil {
+		return Greeks{}, err
+	}
+	sqrtT := math.Sqrt(years)
+	d1 := (math.Log(spot/strike) + (rate-dividendYield+volatility*volatility/2)*years) / (volatility * sqrtT)
+	d2 := d1 - volatility*sqrtT
+	dividendDiscount, discount := math.Exp(-dividendYield*years), math.Exp(-rate*years)
+
+	greeks := Greeks{
+		Gamma: dividendDiscount * normalDensity(d1) / (spot * volatility * sqrtT),
+		Vega:  spot * dividendDiscount * normalDensity(d1) * sqrtT,
+	}
+	decay := -spot * dividendDiscount * normalDensity(d1) * volatility / (2 * sqrtT)
+	if optionType == OptionCall {
+		greeks.Price = spot*dividendDiscount*normalDistribution(d1) - strike*discount*normalDistribution(d2)
+		greeks.Delta = dividendDiscount * normalDistribution(d1)
+		greeks.Theta = decay + dividendYield*spot*dividendDiscount*normalDistribution(d1) - rate*strike*discount*normalDistribution(d2)
+		greeks.Rho = strike * years * discount * normalDistribution(d2)
+	} else {
+		greeks.Price = strike*discount*normalDistribution(-d2) - spot*dividendDiscount*normalDistribution(-d1)
+		greeks.Delta = -dividendDiscount * normalDistribution(-d1)
+		greeks.Theta = decay - dividendYield*spot*dividendDiscount*normalDistribution(-d1) + rate*strike*discount*normalDistribution(-d2)
+		greeks.Rho = -strike * years * discount * normalDistribution(-d2)
+	}
+	return greeks, nil
+}
+
+// GarmanKohlhagen returns the price and Greeks of a European option on a currency, under the Garman-Kohlhagen model.
+// Prices are in domestic currency per unit of foreign currency.
+//
+// spot and strike are exchange rates (domestic currency per unit of foreign currency), and years is the time to expiry.
+// domesticRate, foreignRate and volatility are annual and continuously compounded.
+func GarmanKohlhagen(optionType int, spot float64, strike float64, years float64, domesticRate float64, foreignRate float64, volatility float64) (Greeks, error) {
+	return BlackScholes(optionType, spot, strike, years, domesticRate, foreignRate, volatility)
+}
+
+// ImpliedVolatility returns the volatility for which the Black-Scholes-Merton price of a European option equals price.
+// For currency options, pass the foreign interest rate as dividendYield.
+//
+// The search starts at the Corrado-Miller approximation and uses Newton's method on vega,
+// falling back to Brent's method when vega is too small for it to converge (such as deep in or out of the money).
+// The price must lie strictly between the no-arbitrage bounds of the option.
+func ImpliedVolatility(optionType int, price float64, spot float64, strike float64, years float64, rate float64, dividendYield float64) (float64, error) {
+	if err := validateOption(optionType, spot, strike, years, 1); err != nil {
+		return 0, err
+	}
+	discountedSpot, discountedStrike := spot*math.Exp(-dividendYield*years), strike*math.Exp(-rate*years)
+	lower, upper := math.Max(discountedSpot-discountedStrike, 0), discountedSpot
+	if optionType == OptionPut {
+		lower, upper = math.Max(discountedStrike-discountedSpot, 0), discountedStrike
+	}
+	if price <= lower {
+		return 0, errors.New("the price must be above the option's lower no-arbitrage bound")
+	}
+	if price >= upper {
+		return 0, errors.New("the price must be below the option's upper no-arbitrage bound")
+	}
+
+	function := func(volatility float64) float64 {
+		greeks, _ := BlackScholes(optionType, spot, strike, years, rate, dividendYield, volatility)
+		return greeks.Price - price
+	}
+	derivative := func(volatility float64) float64 {
+		greeks, _ := BlackScholes(optionType, spot, strike, years, rate, dividendYield, volatility)
+		return greeks.Vega
+	}
+	guess := impliedVolatilityGuess(optionType, price, discountedSpot, discountedStrike, years)
+	if volatility, err := newton(guess, function, derivative, 0); err == nil && volatility > 0 && math.Abs(function(volatility)) < Precision {
+		return volatility, nil
+	}
+
+	low, high := 1e-6, 1.0
+	for i := 0; function(high) < 0; i++ {
+		if i == MaxIterations {
+			return 0, errors.New("couldn't bracket the implied volatility")
+		}
+		low, high = high, 2*high
+	}
+	return brent(function, low, high)
+}
+
+// impliedVolatilityGuess returns the Corrado-Miller approximation of the implied volatility, which extends the Brenner-Subrahmanyam one to options away from the money.
+// The put price is converted to a call price through put-call parity.
+func impliedVolatilityGuess(optionType int, price float64, discountedSpot float64, discountedStrike float64, years float64) float64 {
+	if optionType == OptionPut {
+		price += discountedSpot - discountedStrike
+	}
+	moneyness := (discountedSpot - discountedStrike) / 2
+	discriminant := (price-moneyness)*(price-moneyness) - 4*moneyness*moneyness/math.Pi
+	if discriminant < 0 {
+		// Brenner-Subrahmanyam
+		return math.Sqrt(2*math.Pi/years) * price / discountedSpot
+	}
+	guess := math.Sqrt(2*math.Pi) / (discountedSpot + discountedStrike) * (price - moneyness + math.Sqrt(discriminant)) / math.Sqrt(years)
+	if guess <= 0 {
+		return math.Sqrt(2*math.Pi/years) * price / discountedSpot
+	}
+	return guess
+}
+
+// normalDistribution returns the cumulative distribution function of the standard normal distribution
+func normalDistribution(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// normalDensity returns the probability density function of the standard normal distribution
+func normalDensity(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func validateOption(optionType int, spot float64, strike float64, years float64, volatility float64) error {
+	if err := validateOptionTerms(optionType, years, volatility); err != nil {
+		return err
+	}
+	if spot <= 0 || strike <= 0 {
+		return errors.New("spot and strike must be positive")
+	}
+	return nil
+}
+
+// validateOptionTerms checks the parameters shared by all the option models, whatever the underlying
+func validateOptionTerms(optionType int, years float64, volatility float64) error {
+	if optionType != OptionCall && optionType != OptionPut {
+		return errors.New("option type must be call or put")
+	}
+	if years <= 0 {
+		return errors.New("time to expiry must be positive")
+	}
+	if volatility <= 0 {
+		return errors.New("volatility must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlackScholes(t *testing.T) {
+	var tests = []struct {
+		optionType    int
+		spot          float64
+		strike        float64
+		years         float64
+		rate          float64
+		dividendYield float64
+		volatility    float64
+		want          float64
+	}{
+		{OptionCall, 42, 40, 0.5, 0.1, 0, 0.2, 4.759422},
+		{OptionPut, 42, 40, 0.5, 0.1, 0, 0.2, 0.808599},
+		{OptionPut, 100, 95, 0.5, 0.1, 0.05, 0.2, 2.464788},
+	}
+
+	for _, test := range tests {
+		if got, err := BlackScholes(test.optionType, test.spot, test.strike, test.years, test.rate, test.dividendYield, test.volatility); err != nil || math.Abs(test.want-got.Price) > Precision {
+			t.Errorf("BlackScholes(%d, %f, %f, %f, %f, %f, %f) = %f, %v", test.optionType, test.spot, test.strike, test.years, test.rate, test.dividendYield, test.volatility, got.Price, err)
+		}
+	}
+
+	if _, err := BlackScholes(2, 42, 40, 0.5, 0.1, 0, 0.2); err == nil {
+		t.Error("An invalid option type should return an error")
+	}
+	if _, err := BlackScholes(OptionCall, 42, 40, 0, 0.1, 0, 0.2); err == nil {
+		t.Error("A zero time to expiry should return an error")
+	}
+	if _, err := BlackScholes(OptionCall, 42, 40, 0.5, 0.1, 0, 0); err == nil {
+		t.Error("A zero volatility should return an error")
+	}
+}
+
+func TestBlackScholesPutCallParity(t *testing.T) {
+	for _, strike := range []float64{60, 95, 100, 140} {
+		for _, years := range []float64{0.1, 1, 5} {
+			call, _ := BlackScholes(OptionCall, 100, strike, years, 0.04, 0.02, 0.3)
+			put, _ := BlackScholes(OptionPut, 100, strike, years, 0.04, 0.02, 0.3)
+			// C - P = S e^(-qT) - K e^(-rT)
+			if want := 100*math.Exp(-0.02*years) - strike*math.Exp(-0.04*years); math.Abs(want-(call.Price-put.Price)) > Precision {
+				t.Errorf("call - put with strike %f and %f years = %f, want %f", strike, years, call.Price-put.Price, want)
+			}
+			if want := math.Exp(-0.02 * years); math.Abs(want-(call.Delta-put.Delta)) > Precision {
+				t.Errorf("call delta - put delta with strike %f and %f years = %f, want %f", strike, years, call.Delta-put.Delta, want)
+			}
+			if math.Abs(call.Gamma-put.Gamma) > Precision || math.Abs(call.Vega-put.Vega) > Precision {
+				t.Errorf("call and put gamma and vega with strike %f and %f years differ: %v, %v", strike, years, call, put)
+			}
+		}
+	}
+}
+
+func TestBlackScholesGreeks(t *testing.T) {
+	const h = 1e-5
+	spot, strike, years, rate, dividendYield, volatility := 100.0, 105.0, 0.75, 0.03, 0.015, 0.25
+	price := func(optionType int, spot float64, years float64, rate float64, volatility float64) float64 {
+		greeks, _ := BlackScholes(optionType, spot, strike, years, rate, dividendYield, volatility)
+		return greeks.Price
+	}
+	for _, optionType := range []int{OptionCall, OptionPut} {
+		greeks, err := BlackScholes(optionType, spot, strike, years, rate, dividendYield, volatility)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tests = []struct {
+			name string
+			got  float64
+			want float64
+		}{
+			{"delta", greeks.Delta, (price(optionType, spot+h, years, rate, volatility) - price(optionType, spot-h, years, rate, volatility)) / (2 * h)},
+			{"gamma", greeks.Gamma, (price(optionType, spot+1e-3, years, rate, volatility) - 2*greeks.Price + price(optionType, spot-1e-3, years, rate, volatility)) / 1e-6},
+			{"vega", greeks.Vega, (price(optionType, spot, years, rate, volatility+h) - price(optionType, spot, years, rate, volatility-h)) / (2 * h)},
+			{"theta", greeks.Theta, -(price(optionType, spot, years+h, rate, volatility) - price(optionType, spot, years-h, rate, volatility)) / (2 * h)},
+			{"rho", greeks.Rho, (price(optionType, spot, years, rate+h, volatility) - price(optionType, spot, years, rate-h, volatility)) / (2 * h)},
+		}
+		for _, test := range tests {
+			if math.Abs(test.want-test.got) > 1e-4 {
+				t.Errorf("BlackScholes(%d) %s = %f, want %f", optionType, test.name, test.got, test.want)
+			}
+		}
+	}
+}
+
+func TestGarmanKohlhagen(t *testing.T) {
+	if got, err := GarmanKohlhagen(OptionCall, 1.56, 1.60, 0.5, 0.06, 0.08, 0.12); err != nil || math.Abs(0.029099-got.Price) > Precision {
+		t.Errorf("GarmanKohlhagen(%d, %f, %f, %f, %f, %f, %f) = %f, %v", OptionCall, 1.56, 1.60, 0.5, 0.06, 0.08, 0.12, got.Price, err)
+	}
+	// put-call parity with both interest rates
+	call, _ := GarmanKohlhagen(OptionCall, 1.56, 1.60, 0.5, 0.06, 0.08, 0.12)
+	put, _ := GarmanKohlhagen(OptionPut, 1.56, 1.60, 0.5, 0.06, 0.08, 0.12)
+	if want := 1.56*math.Exp(-0.08*0.5) - 1.60*math.Exp(-0.06*0.5); math.Abs(want-(call.Price-put.Price)) > Precision {
+		t.Errorf("call - put = %f, want %f", call.Price-put.Price, want)
+	}
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	if got, err := ImpliedVolatility(OptionCall, 4.759422, 42, 40, 0.5, 0.1, 0); err != nil || math.Abs(0.2-got) > Precision {
+		t.Errorf("ImpliedVolatility(%d, %f, %f, %f, %f, %f, %f) = %f, %v", OptionCall, 4.759422, 42.0, 40.0, 0.5, 0.1, 0.0, got, err)
+	}
+
+	// the volatility used to price the option is recovered, including deep in and out of the money options with a tiny vega
+	for _, optionType := range []int{OptionCall, OptionPut} {
+		for _, strike := range []float64{30, 80, 100, 125, 250} {
+			for _, years := range []float64{0.05, 1, 10} {
+				for _, volatility := range []float64{0.05, 0.3, 1.5} {
+					greeks, _ := BlackScholes(optionType, 100, strike, years, 0.03, 0.01, volatility)
+					if greeks.Vega < 1e-6 {
+						continue
+					}
+					if got, err := ImpliedVolatility(optionType, greeks.Price, 100, strike, years, 0.03, 0.01); err != nil || math.Abs(volatility-got) > Precision {
+						t.Errorf("ImpliedVolatility(%d, %f, %f, %f, %f, %f, %f) = %f, %v, want %f", optionType, greeks.Price, 100.0, strike, years, 0.03, 0.01, got, err, volatility)
+					}
+				}
+			}
+		}
+	}
+
+	var errorTests = []struct {
+		optionType int
+		price      float64
+	}{
+		// below intrinsic value
+		{OptionCall, 1.9},
+		{OptionPut, 0},
+		// above the spot price
+		{OptionCall, 42},
+		// above the discounted strike
+		{OptionPut, 38.1},
+	}
+	for _, test := range errorTests {
+		if _, err := ImpliedVolatility(test.optionType, test.price, 42, 40, 0.5, 0.1, 0); err == nil {
+			t.Errorf("ImpliedVolatility(%d, %f) outside the no-arbitrage bounds should return an error", test.optionType, test.price)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package fin
+
+import (
+	"errors"
+	"math"
+)
+
+// These constants are used in the TreeOption type (field "Exercise"), for specifying when the option can be exercised:
+const (
+	// Only at expiry
+	ExerciseEuropean = iota
+	// At any time until expiry
+	ExerciseAmerican
+	// At the given exercise times and at expiry
+	ExerciseBermudan
+)
+
+// Dividend is a cash dividend paid by the underlying asset of an option, Time years from valuation.
+type Dividend struct {
+	Time   float64
+	Amount float64
+}
+
+// TreeOption is an option priced on a recombining tree, which allows valuing early exercise.
+//
+// The underlying asset can pay both a continuous dividend yield and discrete cash dividends. The dividends are handled with the escrowed dividend model:
+// the tree is built for the asset price less the present value of the dividends paid until expiry, which are added back at each node.
+type TreeOption struct {
+	// Type is OptionCall or OptionPut
+	Type int
+	// Exercise is one of the Exercise* constants
+	Exercise int
+	Strike   float64
+	// Years is the time to expiry
+	Years float64
+	// ExerciseTimes are the times (in years) at which a Bermudan option can be exercised before expiry. They're rounded to the nearest step.
+	ExerciseTimes []float64
+	Dividends     []Dividend
+}
+
+// BinomialPrice returns the price of the option on a Cox-Ross-Rubinstein binomial tree with the given number of steps.
+// rate, dividendYield and volatility are annual and continuously compounded.
+func (o TreeOption) BinomialPrice(spot float64, rate float64, dividendYield float64, volatility float64, steps int) (float64, error) {
+	escrowed, err := o.validate(spot, rate, volatility, steps)
+	if err != nil {
+		return 0, err
+	}
+	dt := o.Years / float64(steps)
+	up := math.Exp(volatility * math.Sqrt(dt))
+	p := (math.Exp((rate-dividendYield)*dt) - 1/up) / (up - 1/up)
+	if p <= 0 || p >= 1 {
+		return 0, errors.New("too few steps for the given rates and volatility")
+	}
+	discount := math.Exp(-rate * dt)
+	exercisable := o.exercisable(steps)
+
+	values := make([]float64, steps+1)
+	for j := range values {
+		values[j] = o.payoff(escrowed*math.Pow(up, float64(2*j-steps)) + o.dividendsAfter(o.Years, rate))
+	}
+	for i := steps - 1; i >= 0; i-- {
+		dividends := o.dividendsAfter(float64(i)*dt, rate)
+		for j := 0; j <= i; j++ {
+			values[j] = discount * (p*values[j+1] + (1-p)*values[j])
+			if exercisable[i] {
+				values[j] = math.Max(values[j], o.payoff(escrowed*math.Pow(up, float64(2*j-i))+dividends))
+			}
+		}
+	}
+	return values[0], nil
+}
+
+// TrinomialPrice returns the price of the option on a trinomial tree with the given number of steps, where the logarithm of the asset price
+// moves up, down or stays at each step.
+// rate, dividendYield and volatility are annual and continuously compounded.
+func (o TreeOption) TrinomialPrice(spot float64, rate float64, dividendYield float64, volatility float64, steps int) (float64, error) {
+	escrowed, err := o.validate(spot, rate, volatility, steps)
+	if err != nil {
+		return 0, err
+	}
+	dt := o.Years / float64(steps)
+	dx := volatility * math.Sqrt(3*dt)
+	drift := (rate - dividendYield - volatility*volatility/2) * math.Sqrt(dt/(12*volatility*volatility))
+	pUp, pMiddle, pDown := 1.0/6+drift, 2.0/3, 1.0/6-drift
+	if pUp <= 0 || pDown <= 0 {
+		return 0, errors.New("too few steps for the given rates and volatility")
+	}
+	discount := math.Exp(-rate * dt)
+	exercisable := o.exercisable(steps)
+
+	// node j of step i is at j-i moves up from the initial price
+	values := make([]float64, 2*steps+1)
+	for j := range values {
+		values[j] = o.payoff(escrowed*math.Exp(float64(j-steps)*dx) + o.dividendsAfter(o.Years, rate))
+	}
+	for i := steps - 1; i >= 0; i-- {
+		dividends := o.dividendsAfter(float64(i)*dt, rate)
+		for j := 0; j <= 2*i; j++ {
+			values[j] = discount * (pUp*values[j+2] + pMiddle*values[j+1] + pDown*values[j])
+			if exercisable[i] {
+				values[j] = math.Max(values[j], o.payoff(escrowed*math.Exp(float64(j-i)*dx)+dividends))
+			}
+		}
+	}
+	return values[0], nil
+}
+
+func (o TreeOption) payoff(price float64) float64 {
+	if o.Type == OptionCall {
+		return math.Max(price-o.Strike, 0)
+	}
+	return math.Max(o.Strike-price, 0)
+}
+
+// dividendsAfter returns the value at time t of the dividends paid after t and until expiry
+func (o TreeOption) dividendsAfter(t float64, rate float64) float64 {
+	pv := 0.0
+	for _, dividend := range o.Dividends {
+		if dividend.Time > t && dividend.Time <= o.Years {
+			pv += dividend.Amount * math.Exp(-rate*(dividend.Time-t))
+		}
+	}
+	return pv
+}
+
+// exercisable returns whether the option can be exercised early at each step before expiry
+func (o TreeOption) exercisable(steps int) []bool {
+	exercisable := make([]bool, steps)
+	switch o.Exercise {
+	case ExerciseAmerican:
+		for i := range exercisable {
+			exercisable[i] = true
+		}
+	case ExerciseBermudan:
+		for _, t := range o.ExerciseTimes {
+			if step := int(math.Round(t / o.Years * float64(steps))); step >= 0 && step < steps {
+				exercisable[step] = true
+			}
+		}
+	}
+	return exercisable
+}
+
+// validate checks the option and the pricing parameters, and returns the asset price less the present value of the dividends
+func (o TreeOption) validate(spot float64, rate float64, volatility float64, steps int) (float64, error) {
+	if err := validateOption(o.Type, spot, o.Strike, o.Years, volatility); err != nil {
+		return 0, err
+	}
+	if o.Exercise < ExerciseEuropean || o.Exercise > ExerciseBermudan {
+		return 0, errors.New("invalid exercise style")
+	}
+	if steps < 1 {
+		return 0, errors.New("there must be at least one step")
+	}
+	for _, dividend := range o.Dividends {
+		if dividend.Time < 0 || dividend.Amount < 0 {
+			return 0, errors.New("dividends can't be negative nor paid before valuation")
+		}
+	}
+	escrowed := spot - o.dividendsAfter(0, rate)
+	if escrowed <= 0 {
+		return 0, errors.New("the dividends can't be worth more than the asset")
+	}
+	return escrowed, nil
+}
@@ -0,0 +1,110 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTreeOptionEuropean(t *testing.T) {
+	// with many steps, the trees converge to Black-Scholes
+	for _, optionType := range []int{OptionCall, OptionPut} {
+		for _, strike := range []float64{80, 100, 120} {
+			option := TreeOption{Type: optionType, Exercise: ExerciseEuropean, Strike: strike, Years: 1.5}
+			want, _ := BlackScholes(optionType, 100, strike, 1.5, 0.05, 0.02, 0.25)
+			if got, err := option.BinomialPrice(100, 0.05, 0.02, 0.25, 2000); err != nil || math.Abs(want.Price-got) > 5e-3 {
+				t.Errorf("%v.BinomialPrice() = %f, %v, want %f", option, got, err, want.Price)
+			}
+			if got, err := option.TrinomialPrice(100, 0.05, 0.02, 0.25, 1000); err != nil || math.Abs(want.Price-got) > 5e-3 {
+				t.Errorf("%v.TrinomialPrice() = %f, %v, want %f", option, got, err, want.Price)
+			}
+		}
+	}
+
+	// with discrete dividends, they converge to Black-Scholes on the asset price less the present value of the dividends
+	option := TreeOption{Type: OptionCall, Exercise: ExerciseEuropean, Strike: 40, Years: 0.5, Dividends: []Dividend{{Time: 2.0 / 12, Amount: 0.5}, {Time: 5.0 / 12, Amount: 0.5}}}
+	escrowed := 40 - 0.5*math.Exp(-0.09*2/12) - 0.5*math.Exp(-0.09*5/12)
+	want, _ := BlackScholes(OptionCall, escrowed, 40, 0.5, 0.09, 0, 0.3)
+	if got, err := option.BinomialPrice(40, 0.09, 0, 0.3, 2000); err != nil || math.Abs(want.Price-got) > 5e-3 {
+		t.Errorf("%v.BinomialPrice() = %f, %v, want %f", option, got, err, want.Price)
+	}
+	if got, err := option.TrinomialPrice(40, 0.09, 0, 0.3, 1000); err != nil || math.Abs(want.Price-got) > 5e-3 {
+		t.Errorf("%v.TrinomialPrice() = %f, %v, want %f", option, got, err, want.Price)
+	}
+}
+
+func TestTreeOptionAmerican(t *testing.T) {
+	put := TreeOption{Type: OptionPut, Exercise: ExerciseAmerican, Strike: 50, Years: 5.0 / 12}
+	// five steps, as in the textbook example
+	if got, err := put.BinomialPrice(50, 0.1, 0, 0.4, 5); err != nil || math.Abs(4.488459-got) > Precision {
+		t.Errorf("%v.BinomialPrice() with 5 steps = %f, %v", put, got, err)
+	}
+	binomial, _ := put.BinomialPrice(50, 0.1, 0, 0.4, 2000)
+	trinomial, _ := put.TrinomialPrice(50, 0.1, 0, 0.4, 1000)
+	if math.Abs(binomial-4.2835) > 2e-3 || math.Abs(trinomial-4.2835) > 2e-3 {
+		t.Errorf("%v.BinomialPrice() = %f, TrinomialPrice() = %f, want 4.2835", put, binomial, trinomial)
+	}
+	european, _ := BlackScholes(OptionPut, 50, 50, 5.0/12, 0.1, 0, 0.4)
+	if binomial <= european.Price {
+		t.Errorf("The American put (%f) should be worth more than the European one (%f)", binomial, european.Price)
+	}
+
+	// an American call on an asset without dividends is never exercised early
+	call := TreeOption{Type: OptionCall, Exercise: ExerciseAmerican, Strike: 50, Years: 5.0 / 12}
+	europeanCall := TreeOption{Type: OptionCall, Exercise: ExerciseEuropean, Strike: 50, Years: 5.0 / 12}
+	american, _ := call.BinomialPrice(50, 0.1, 0, 0.4, 500)
+	if want, _ := europeanCall.BinomialPrice(50, 0.1, 0, 0.4, 500); math.Abs(want-american) > Precision {
+		t.Errorf("%v.BinomialPrice() = %f, want %f", call, american, want)
+	}
+	// but it can be exercised before a large dividend
+	call.Dividends = []Dividend{{Time: 0.25, Amount: 5}}
+	europeanCall.Dividends = call.Dividends
+	american, _ = call.TrinomialPrice(50, 0.1, 0, 0.4, 500)
+	if europeanPrice, _ := europeanCall.TrinomialPrice(50, 0.1, 0, 0.4, 500); american <= europeanPrice {
+		t.Errorf("%v.TrinomialPrice() = %f, should be higher than %f", call, american, europeanPrice)
+	}
+}
+
+func TestTreeOptionBermudan(t *testing.T) {
+	option := TreeOption{Type: OptionPut, Strike: 110, Years: 1}
+	prices := make([]float64, 3)
+	for i, exercise := range []int{ExerciseEuropean, ExerciseBermudan, ExerciseAmerican} {
+		option.Exercise = exercise
+		option.ExerciseTimes = []float64{0.25, 0.5, 0.75}
+		price, err := option.BinomialPrice(100, 0.06, 0, 0.2, 400)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prices[i] = price
+	}
+	if !(prices[0] < prices[1] && prices[1] < prices[2]) {
+		t.Errorf("European, Bermudan and American prices should increase: %v", prices)
+	}
+
+	// a Bermudan option exercisable only at expiry is European
+	option.Exercise = ExerciseBermudan
+	option.ExerciseTimes = nil
+	bermudan, _ := option.TrinomialPrice(100, 0.06, 0, 0.2, 400)
+	option.Exercise = ExerciseEuropean
+	if european, _ := option.TrinomialPrice(100, 0.06, 0, 0.2, 400); math.Abs(european-bermudan) > Precision {
+		t.Errorf("%v.TrinomialPrice() = %f, want %f", option, bermudan, european)
+	}
+}
+
+func TestTreeOptionErrors(t *testing.T) {
+	option := TreeOption{Type: OptionPut, Exercise: ExerciseAmerican, Strike: 50, Years: 1}
+	if _, err := option.BinomialPrice(50, 0.1, 0, 0.4, 0); err == nil {
+		t.Error("Zero steps should return an error")
+	}
+	if _, err := option.TrinomialPrice(50, 2, 0, 0.01, 2); err == nil {
+		t.Error("Negative probabilities should return an error")
+	}
+	option.Dividends = []Dividend{{Time: 0.5, Amount: 60}}
+	if _, err := option.BinomialPrice(50, 0.1, 0, 0.4, 100); err == nil {
+		t.Error("Dividends worth more than the asset should return an error")
+	}
+	option.Dividends = nil
+	option.Exercise = 5
+	if _, err := option.BinomialPrice(50, 0.1, 0, 0.4, 100); err == nil {
+		t.Error("An invalid exercise style should return an error")
+	}
+}
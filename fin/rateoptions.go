@@ -0,0 +1,166 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// These constants are used in the interest rate option functions (parameter "model"), for specifying how the volatility is quoted:
+const (
+	// Lognormal volatility, as in the Black-76 model
+	VolatilityLognormal = iota
+	// Normal (absolute) volatility, as in the Bachelier model
+	VolatilityNormal
+)
+
+// Black76 returns the price of a European option on a forward rate (or price) under the Black-76 model, where the forward is lognormal.
+// A call on a rate is a caplet or a payer swaption, and a put is a floorlet or a receiver swaption.
+//
+// forward and strike must be positive, years is the time to expiry and volatility is the annual lognormal volatility.
+// annuity is the factor applied to the option's undiscounted payoff: the notional times the accrual fraction times the discount factor
+// of the payment date for a caplet, or the notional times the annuity of the underlying swap for a swaption.
+func Black76(optionType int, forward float64, strike float64, years float64, volatility float64, annuity float64) (float64, error) {
+	if err := validateOptionTerms(optionType, years, volatility); err != nil {
+		return 0, err
+	}
+	if forward <= 0 || strike <= 0 {
+		return 0, errors.New("forward and strike must be positive in the lognormal model")
+	}
+	stdDev := volatility * math.Sqrt(years)
+	d1 := (math.Log(forward/strike) + stdDev*stdDev/2) / stdDev
+	d2 := d1 - stdDev
+	if optionType == OptionCall {
+		return annuity * (forward*normalDistribution(d1) - strike*normalDistribution(d2)), nil
+	}
+	return annuity * (strike*normalDistribution(-d2) - forward*normalDistribution(-d1)), nil
+}
+
+// Bachelier returns the price of a European option on a forward rate (or price) under the Bachelier model, where the forward is normally distributed,
+// so that forward and strike can be zero or negative. See Black76 for the meaning of the parameters; volatility is the annual normal volatility.
+func Bachelier(optionType int, forward float64, strike float64, years float64, volatility float64, annuity float64) (float64, error) {
+	if err := validateOptionTerms(optionType, years, volatility); err != nil {
+		return 0, err
+	}
+	stdDev := volatility * math.Sqrt(years)
+	d := (forward - strike) / stdDev
+	if optionType == OptionCall {
+		return annuity * ((forward-strike)*normalDistribution(d) + stdDev*normalDensity(d)), nil
+	}
+	return annuity * ((strike-forward)*normalDistribution(-d) + stdDev*normalDensity(d)), nil
+}
+
+// CapFloor is an interest rate cap (Type OptionCall) or floor (Type OptionPut): a series of options on the floating rate of each period, paying
+// the difference between the rate and Strike times the accrual fraction of the period.
+//
+// The periods are generated between Start and Maturity with Frequency (payments per year) as the floating leg of a Swap, adjusted with Calendar
+// according to Convention (see the BusinessDay* constants), and accrue with the given daycount basis (see the Count* constants).
+// Each rate is fixed at the start of its period and paid at its end.
+type CapFloor struct {
+	Type      int
+	Notional  float64
+	Strike    float64
+	Frequency int
+	Basis     int
+	// CurrentFixing is the floating rate fixed at the start of the period in progress at valuation, if any, whose payoff is already known.
+	// The rates of the periods starting on or after valuation are projected from the forward curve.
+	CurrentFixing float64
+	Start         time.Time
+	Maturity      time.Time
+	Calendar      Calendar
+	Convention    int
+}
+
+// Price returns the value of the cap or floor at valuation, which is taken as the curves' reference date, as the sum of the values of its caplets or floorlets.
+// The periods paid by valuation are ignored, and those whose rate is already fixed (the period in progress, which pays CurrentFixing,
+// or one starting on valuation) are worth their known payoff, discounted from the payment date.
+//
+// The forward rates are projected from the forward curve and the payments discounted with the discount curve; both can be the same curve.
+// volatility is quoted according to model (see the Volatility* constants).
+func (c CapFloor) Price(discount DiscountCurve, forward DiscountCurve, valuation time.Time, model int, volatility float64) (float64, error) {
+	if c.Type != OptionCall && c.Type != OptionPut {
+		return 0, errors.New("option type must be call or put")
+	}
+	if !isValidBasis(c.Basis) {
+		return 0, errors.New("invalid day count basis")
+	}
+	dates, err := GenerateSchedule(c.Start, c.Maturity, c.Frequency, c.Calendar, c.Convention)
+	if err != nil {
+		return 0, err
+	}
+	valuation = civilDate(valuation)
+	price := 0.0
+	for i := 1; i < len(dates); i++ {
+		if !dates[i].After(valuation) {
+			continue
+		}
+		accrual := yearFraction(dates[i-1], dates[i], c.Basis)
+		t1, t2 := curveTime(valuation, dates[i-1]), curveTime(valuation, dates[i])
+		rate := c.CurrentFixing
+		if !dates[i-1].Before(valuation) {
+			rate = (forward.DiscountFactor(t1)/forward.DiscountFactor(t2) - 1) / accrual
+		}
+		annuity := c.Notional * accrual * discount.DiscountFactor(t2)
+		if t1 <= 0 {
+			// the rate is already fixed, so the payoff is known
+			payoff := rate - c.Strike
+			if c.Type == OptionPut {
+				payoff = -payoff
+			}
+			price += annuity * math.Max(payoff, 0)
+			continue
+		}
+		caplet, err := rateOption(model, c.Type, rate, c.Strike, t1, volatility, annuity)
+		if err != nil {
+			return 0, err
+		}
+		price += caplet
+	}
+	return price, nil
+}
+
+// Swaption is a European option, exercisable at Expiry, to enter into Swap: a payer swaption if the swap is a payer swap, or a receiver swaption otherwise.
+// The swap's fixed rate is the strike, and it must start no earlier than Expiry.
+type Swaption struct {
+	Swap   Swap
+	Expiry time.Time
+}
+
+// Price returns the value of the swaption at valuation, which is taken as the curves' reference date, with the swap's par rate as the forward
+// and its annuity (times the notional) as the annuity factor.
+//
+// The forward rates are projected from the forward curve and the payments discounted with the discount curve; both can be the same curve.
+// volatility is quoted according to model (see the Volatility* constants).
+func (s Swaption) Price(discount DiscountCurve, forward DiscountCurve, valuation time.Time, model int, volatility float64) (float64, error) {
+	valuation = civilDate(valuation)
+	expiry := civilDate(s.Expiry)
+	if !expiry.After(valuation) {
+		return 0, errors.New("the expiry must fall after valuation")
+	}
+	if civilDate(s.Swap.Start).Before(expiry) {
+		return 0, errors.New("the swap can't start before the expiry")
+	}
+	rate, err := s.Swap.ParRate(discount, forward, valuation)
+	if err != nil {
+		return 0, err
+	}
+	annuity, err := s.Swap.Annuity(discount, valuation)
+	if err != nil {
+		return 0, err
+	}
+	optionType := OptionCall
+	if s.Swap.Type == SwapReceiver {
+		optionType = OptionPut
+	}
+	return rateOption(model, optionType, rate, s.Swap.FixedRate, curveTime(valuation, expiry), volatility, math.Abs(s.Swap.Notional)*annuity)
+}
+
+func rateOption(model int, optionType int, forward float64, strike float64, years float64, volatility float64, annuity float64) (float64, error) {
+	switch model {
+	case VolatilityLognormal:
+		return Black76(optionType, forward, strike, years, volatility, annuity)
+	case VolatilityNormal:
+		return Bachelier(optionType, forward, strike, years, volatility, annuity)
+	}
+	return 0, errors.New("invalid volatility model")
+}
@@ -0,0 +1,153 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBlack76(t *testing.T) {
+	// caplet on a 3 month rate fixing in one year
+	annuity := 10000 * 0.25 * math.Exp(-0.065*1.25)
+	if got, err := Black76(OptionCall, 0.07, 0.08, 1, 0.2, annuity); err != nil || math.Abs(5.190046-got) > Precision {
+		t.Errorf("Black76(%d, %f, %f, %f, %f, %f) = %f, %v", OptionCall, 0.07, 0.08, 1.0, 0.2, annuity, got, err)
+	}
+	// put-call parity: C - P = annuity (F - K)
+	call, _ := Black76(OptionCall, 0.035, 0.03, 2, 0.3, 4.5)
+	put, _ := Black76(OptionPut, 0.035, 0.03, 2, 0.3, 4.5)
+	if math.Abs(call-put-4.5*0.005) > Precision {
+		t.Errorf("Black76 call - put = %f, want %f", call-put, 4.5*0.005)
+	}
+
+	if _, err := Black76(OptionCall, -0.001, 0.01, 1, 0.2, 1); err == nil {
+		t.Error("A negative forward should return an error")
+	}
+}
+
+func TestBachelier(t *testing.T) {
+	// at the money, the price is annuity * σ √T / √(2π)
+	if got, err := Bachelier(OptionCall, 0.02, 0.02, 4, 0.01, 1000); err != nil || math.Abs(1000*0.02/math.Sqrt(2*math.Pi)-got) > Precision {
+		t.Errorf("Bachelier(%d, %f, %f, %f, %f, %f) = %f, %v", OptionCall, 0.02, 0.02, 4.0, 0.01, 1000.0, got, err)
+	}
+	// negative rates, and put-call parity
+	call, err := Bachelier(OptionCall, -0.004, -0.001, 1.5, 0.006, 4.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	put, _ := Bachelier(OptionPut, -0.004, -0.001, 1.5, 0.006, 4.5)
+	if math.Abs(call-put-4.5*-0.003) > Precision {
+		t.Errorf("Bachelier call - put = %f, want %f", call-put, 4.5*-0.003)
+	}
+	// near the money, it's close to Black-76 with the normal volatility equivalent to the lognormal one
+	black, _ := Black76(OptionCall, 0.05, 0.05, 1, 0.2, 100)
+	if normal, _ := Bachelier(OptionCall, 0.05, 0.05, 1, 0.2*0.05, 100); math.Abs(black-normal) > 1e-3 {
+		t.Errorf("Bachelier() = %f, Black76() = %f", normal, black)
+	}
+
+	if _, err := Bachelier(OptionCall, 0.02, 0.02, 0, 0.01, 1); err == nil {
+		t.Error("A zero time to expiry should return an error")
+	}
+}
+
+func TestCapFloor(t *testing.T) {
+	curve, _ := NewPiecewiseConstantCurve([]float64{1, 5}, []float64{0.03, 0.04})
+	valuation := date(2020, time.January, 1)
+	cap := CapFloor{Type: OptionCall, Notional: 1e6, Strike: 0.035, Frequency: 4, Basis: CountActual360, Start: date(2021, time.January, 1), Maturity: date(2025, time.January, 1)}
+	floor := cap
+	floor.Type = OptionPut
+	swap := Swap{Type: SwapPayer, Notional: 1e6, FixedRate: 0.035, FixedFrequency: 4, FixedBasis: CountActual360, FloatFrequency: 4, FloatBasis: CountActual360, Start: cap.Start, Maturity: cap.Maturity}
+	want, _ := swap.NetPresentValue(curve, curve, valuation)
+
+	for _, model := range []int{VolatilityLognormal, VolatilityNormal} {
+		volatility := 0.25
+		if model == VolatilityNormal {
+			volatility = 0.009
+		}
+		capPrice, err := cap.Price(curve, curve, valuation, model, volatility)
+		if err != nil {
+			t.Fatal(err)
+		}
+		floorPrice, err := floor.Price(curve, curve, valuation, model, volatility)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// cap - floor = payer swap
+		if math.Abs(want-(capPrice-floorPrice)) > Precision {
+			t.Errorf("CapFloor.Price() cap - floor = %f, want %f", capPrice-floorPrice, want)
+		}
+	}
+
+	// a single caplet
+	caplet := CapFloor{Type: OptionCall, Notional: 1e6, Strike: 0.035, Frequency: 4, Basis: CountActual360, Start: date(2021, time.January, 1), Maturity: date(2021, time.April, 1)}
+	t1, t2 := curveTime(valuation, caplet.Start), curveTime(valuation, caplet.Maturity)
+	accrual := 90.0 / 360
+	forward := (curve.DiscountFactor(t1)/curve.DiscountFactor(t2) - 1) / accrual
+	want, _ = Black76(OptionCall, forward, 0.035, t1, 0.25, 1e6*accrual*curve.DiscountFactor(t2))
+	if got, err := caplet.Price(curve, curve, valuation, VolatilityLognormal, 0.25); err != nil || math.Abs(want-got) > Precision {
+		t.Errorf("CapFloor.Price() for a caplet = %f, %v, want %f", got, err, want)
+	}
+	// a period already fixed is worth its known payoff
+	caplet.CurrentFixing = 0.045
+	seasoned := date(2021, time.February, 1)
+	want = 1e6 * accrual * 0.01 * curve.DiscountFactor(curveTime(seasoned, caplet.Maturity))
+	if got, err := caplet.Price(curve, curve, seasoned, VolatilityLognormal, 0.25); err != nil || math.Abs(want-got) > Precision {
+		t.Errorf("CapFloor.Price() after the fixing = %f, %v, want %f", got, err, want)
+	}
+	floorlet := caplet
+	floorlet.Type = OptionPut
+	if got, err := floorlet.Price(curve, curve, seasoned, VolatilityLognormal, 0.25); err != nil || got != 0 {
+		t.Errorf("CapFloor.Price() after the fixing of an out of the money floorlet = %f, %v", got, err)
+	}
+	// on the fixing date, the payoff is the intrinsic value
+	rate := (1/curve.DiscountFactor(curveTime(caplet.Start, caplet.Maturity)) - 1) / accrual
+	want = 1e6 * accrual * (0.035 - rate) * curve.DiscountFactor(curveTime(caplet.Start, caplet.Maturity))
+	if got, err := floorlet.Price(curve, curve, caplet.Start, VolatilityLognormal, 0.25); err != nil || want <= 0 || math.Abs(want-got) > Precision {
+		t.Errorf("CapFloor.Price() on the fixing date = %f, %v, want %f", got, err, want)
+	}
+	// and once paid, it's worth nothing
+	if got, err := caplet.Price(curve, curve, caplet.Maturity, VolatilityLognormal, 0.25); err != nil || got != 0 {
+		t.Errorf("CapFloor.Price() after the payment = %f, %v", got, err)
+	}
+	if _, err := caplet.Price(curve, curve, valuation, 2, 0.25); err == nil {
+		t.Error("An invalid model should return an error")
+	}
+}
+
+func TestSwaption(t *testing.T) {
+	curve, _ := NewPiecewiseConstantCurve([]float64{1, 5}, []float64{0.03, 0.04})
+	valuation := date(2020, time.January, 1)
+	swap := Swap{Type: SwapPayer, Notional: 1e6, FixedRate: 0.04, FixedFrequency: 1, FixedBasis: CountNasd, FloatFrequency: 4, FloatBasis: CountActual360, Start: date(2022, time.January, 1), Maturity: date(2027, time.January, 1)}
+	payer := Swaption{Swap: swap, Expiry: date(2022, time.January, 1)}
+	receiver := payer
+	receiver.Swap.Type = SwapReceiver
+
+	rate, _ := swap.ParRate(curve, curve, valuation)
+	annuity, _ := swap.Annuity(curve, valuation)
+	expiry := curveTime(valuation, payer.Expiry)
+	want, _ := Black76(OptionCall, rate, 0.04, expiry, 0.2, 1e6*annuity)
+	if got, err := payer.Price(curve, curve, valuation, VolatilityLognormal, 0.2); err != nil || math.Abs(want-got) > Precision {
+		t.Errorf("Swaption.Price() = %f, %v, want %f", got, err, want)
+	}
+
+	// payer - receiver = forward starting payer swap
+	swapValue, _ := swap.NetPresentValue(curve, curve, valuation)
+	for _, model := range []int{VolatilityLognormal, VolatilityNormal} {
+		payerPrice, err := payer.Price(curve, curve, valuation, model, 0.2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		receiverPrice, err := receiver.Price(curve, curve, valuation, model, 0.2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(swapValue-(payerPrice-receiverPrice)) > Precision {
+			t.Errorf("Swaption.Price() payer - receiver = %f, want %f", payerPrice-receiverPrice, swapValue)
+		}
+	}
+
+	invalid := payer
+	invalid.Expiry = date(2023, time.January, 1)
+	if _, err := invalid.Price(curve, curve, valuation, VolatilityLognormal, 0.2); err == nil {
+		t.Error("A swap starting before the expiry should return an error")
+	}
+}
@@ -0,0 +1,153 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// In the portfolio return functions, external flows are positive when money goes into the portfolio (contributions) and negative when it goes out (withdrawals).
+// A valuation at a given date is assumed to already include the flows occurring on that date (end of day flows).
+
+// ModifiedDietzReturn returns the return of a portfolio over a period using the Modified Dietz method,
+// where each external flow is weighted by the fraction of the period it remained in the portfolio.
+// Flows must occur after startDate and no later than endDate.
+func ModifiedDietzReturn(startValue float64, endValue float64, startDate time.Time, endDate time.Time, flows []float64, flowDates []time.Time) (float64, error) {
+	if len(flows) != len(flowDates) {
+		return 0, errors.New("flows and flowDates must have the same length")
+	}
+	if !civilDate(startDate).Before(civilDate(endDate)) {
+		return 0, errors.New("the start date must precede the end date")
+	}
+	return modifiedDietz(startValue, endValue, startDate, endDate, flows, flowDates)
+}
+
+// SubPeriodReturns returns the returns of a portfolio between each pair of consecutive valuations.
+// When external flows occur between valuation dates, the sub-period return is approximated with the Modified Dietz method;
+// when they all occur on valuation dates, the returns are exact.
+//
+// values are the market values of the portfolio on valuationDates, which must be in ascending order.
+//
+// flows are the external flows, occurring on flowDates, which must fall after the first valuation date and no later than the last one.
+func SubPeriodReturns(values []float64, valuationDates []time.Time, flows []float64, flowDates []time.Time) ([]float64, error) {
+	if len(values) != len(valuationDates) {
+		return nil, errors.New("values and valuationDates must have the same length")
+	}
+	if len(values) < 2 {
+		return nil, errors.New("at least two valuations are required")
+	}
+	if len(flows) != len(flowDates) {
+		return nil, errors.New("flows and flowDates must have the same length")
+	}
+	for i := 1; i < len(valuationDates); i++ {
+		if !civilDate(valuationDates[i-1]).Before(civilDate(valuationDates[i])) {
+			return nil, errors.New("valuation dates must be in ascending order")
+		}
+	}
+	first, last := civilDate(valuationDates[0]), civilDate(valuationDates[len(valuationDates)-1])
+	for _, date := range flowDates {
+		if !civilDate(date).After(first) || civilDate(date).After(last) {
+			return nil, errors.New("flows must occur after the first valuation date and no later than the last one")
+		}
+	}
+
+	returns := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		start, end := civilDate(valuationDates[i-1]), civilDate(valuationDates[i])
+		periodFlows := make([]float64, 0)
+		periodDates := make([]time.Time, 0)
+		for j, date := range flowDates {
+			if date := civilDate(date); date.After(start) && !date.After(end) {
+				periodFlows = append(periodFlows, flows[j])
+				periodDates = append(periodDates, date)
+			}
+		}
+		r, err := modifiedDietz(values[i-1], values[i], start, end, periodFlows, periodDates)
+		if err != nil {
+			return nil, err
+		}
+		returns[i-1] = r
+	}
+	return returns, nil
+}
+
+// TimeWeightedReturn returns the time-weighted return of a portfolio, chain-linking the returns of the sub-periods between valuations.
+// See SubPeriodReturns for the meaning of the parameters.
+func TimeWeightedReturn(values []float64, valuationDates []time.Time, flows []float64, flowDates []time.Time) (float64, error) {
+	returns, err := SubPeriodReturns(values, valuationDates, flows, flowDates)
+	if err != nil {
+		return 0, err
+	}
+	return LinkedReturn(returns), nil
+}
+
+// LinkedReturn returns the cumulative return of consecutive periods by geometrically linking their returns.
+func LinkedReturn(returns []float64) float64 {
+	linked := 1.0
+	for _, r := range returns {
+		linked *= 1 + r
+	}
+	return linked - 1
+}
+
+// AnnualizedReturn returns the annual rate equivalent to a cumulative return obtained between two dates,
+// measuring the time between them with the given daycount basis (see the Count* constants).
+func AnnualizedReturn(cumulativeReturn float64, startDate time.Time, endDate time.Time, basis int) (float64, error) {
+	years, err := YearFraction(startDate, endDate, basis)
+	if err != nil {
+		return 0, err
+	}
+	if years <= 0 {
+		return 0, errors.New("the start date must precede the end date")
+	}
+	if cumulativeReturn <= -1 {
+		return 0, errors.New("the cumulative return must be greater than -1")
+	}
+	return math.Pow(1+cumulativeReturn, 1/years) - 1, nil
+}
+
+// MoneyWeightedReturn returns the money-weighted (annual) return of a portfolio, that is the internal rate of return of its starting value, external flows and ending value.
+// Flows must occur after startDate and no later than endDate.
+// Guess is a guess for the rate, used as a starting point for the iterative algorithm.
+func MoneyWeightedReturn(startValue float64, endValue float64, startDate time.Time, endDate time.Time, flows []float64, flowDates []time.Time, guess float64) (float64, error) {
+	if len(flows) != len(flowDates) {
+		return 0, errors.New("flows and flowDates must have the same length")
+	}
+	start, end := civilDate(startDate), civilDate(endDate)
+	if !start.Before(end) {
+		return 0, errors.New("the start date must precede the end date")
+	}
+	for _, date := range flowDates {
+		if !civilDate(date).After(start) || civilDate(date).After(end) {
+			return 0, errors.New("flows must occur after the start date and no later than the end date")
+		}
+	}
+	values := []float64{-startValue}
+	dates := []time.Time{startDate}
+	for i, flow := range flows {
+		values = append(values, -flow)
+		dates = append(dates, flowDates[i])
+	}
+	values = append(values, endValue)
+	dates = append(dates, endDate)
+	return ScheduledInternalRateOfReturn(values, dates, guess)
+}
+
+func modifiedDietz(startValue float64, endValue float64, startDate time.Time, endDate time.Time, flows []float64, flowDates []time.Time) (float64, error) {
+	start, end := civilDate(startDate), civilDate(endDate)
+	days := float64(actualDays(start, end))
+	netFlows, weightedFlows := 0.0, 0.0
+	for i, flow := range flows {
+		date := civilDate(flowDates[i])
+		if !date.After(start) || date.After(end) {
+			return 0, errors.New("flows must occur after the start date and no later than the end date")
+		}
+		netFlows += flow
+		weightedFlows += flow * float64(actualDays(date, end)) / days
+	}
+	invested := startValue + weightedFlows
+	if invested == 0 {
+		return 0, errors.New("the average invested capital can't be zero")
+	}
+	return (endValue - startValue - netFlows) / invested, nil
+}
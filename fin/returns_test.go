@@ -0,0 +1,132 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestModifiedDietzReturn(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		startValue float64
+		endValue   float64
+		flows      []float64
+		flowDates  []time.Time
+		want       float64
+	}{
+		{100, 115, []float64{10}, []time.Time{time.Date(2020, time.January, 16, 0, 0, 0, 0, time.UTC)}, 0.047546},
+		{100, 115, []float64{10}, []time.Time{end}, 0.05},
+		{100, 110, []float64{}, []time.Time{}, 0.1},
+	}
+
+	for _, test := range tests {
+		if got, _ := ModifiedDietzReturn(test.startValue, test.endValue, start, end, test.flows, test.flowDates); math.Abs(test.want-got) > Precision {
+			t.Errorf("ModifiedDietzReturn(%f, %f, %v, %v, %v, %v) = %f", test.startValue, test.endValue, start, end, test.flows, test.flowDates, got)
+		}
+	}
+
+	if _, err := ModifiedDietzReturn(100, 115, start, end, []float64{10}, []time.Time{start}); err == nil {
+		t.Error("If a flow doesn't occur after the start date, it must return an error")
+	}
+
+	if _, err := ModifiedDietzReturn(100, 115, end, start, []float64{}, []time.Time{}); err == nil {
+		t.Error("If the start date doesn't precede the end date, it must return an error")
+	}
+}
+
+func TestTimeWeightedReturn(t *testing.T) {
+	valuationDates := []time.Time{
+		time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.January, 16, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+	}
+	values := []float64{100, 108, 115}
+	flows := []float64{10}
+	flowDates := []time.Time{time.Date(2020, time.January, 16, 0, 0, 0, 0, time.UTC)}
+
+	returns, err := SubPeriodReturns(values, valuationDates, flows, flowDates)
+	if err != nil || math.Abs(returns[0]+0.02) > Precision || math.Abs(returns[1]-0.064815) > Precision {
+		t.Errorf("SubPeriodReturns(%v, %v, %v, %v) = %v, %v", values, valuationDates, flows, flowDates, returns, err)
+	}
+
+	if got, _ := TimeWeightedReturn(values, valuationDates, flows, flowDates); math.Abs(got-0.043519) > Precision {
+		t.Errorf("TimeWeightedReturn(%v, %v, %v, %v) = %f", values, valuationDates, flows, flowDates, got)
+	}
+
+	// without the intermediate valuation it falls back to Modified Dietz
+	if got, _ := TimeWeightedReturn([]float64{100, 115}, []time.Time{valuationDates[0], valuationDates[2]}, flows, flowDates); math.Abs(got-0.047546) > Precision {
+		t.Errorf("TimeWeightedReturn without intermediate valuation = %f", got)
+	}
+
+	if _, err := TimeWeightedReturn(values, []time.Time{valuationDates[1], valuationDates[0], valuationDates[2]}, flows, flowDates); err == nil {
+		t.Error("If the valuation dates aren't in ascending order, it must return an error")
+	}
+
+	if _, err := TimeWeightedReturn(values, valuationDates, flows, []time.Time{valuationDates[0]}); err == nil {
+		t.Error("If a flow occurs on the first valuation date, it must return an error")
+	}
+}
+
+func TestLinkedReturn(t *testing.T) {
+	var tests = []struct {
+		returns []float64
+		want    float64
+	}{
+		{[]float64{0.1, 0.1}, 0.21},
+		{[]float64{0.1, -0.1}, -0.01},
+		{[]float64{}, 0},
+	}
+
+	for _, test := range tests {
+		if got := LinkedReturn(test.returns); math.Abs(test.want-got) > Precision {
+			t.Errorf("LinkedReturn(%v) = %f", test.returns, got)
+		}
+	}
+}
+
+func TestAnnualizedReturn(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		cumulativeReturn float64
+		basis            int
+		want             float64
+	}{
+		{0.05, CountActual365, 0.024661},
+		{0.21, CountActualActual, 0.1},
+	}
+
+	for _, test := range tests {
+		if got, _ := AnnualizedReturn(test.cumulativeReturn, start, end, test.basis); math.Abs(test.want-got) > Precision {
+			t.Errorf("AnnualizedReturn(%f, %v, %v, %d) = %f", test.cumulativeReturn, start, end, test.basis, got)
+		}
+	}
+
+	if _, err := AnnualizedReturn(0.05, end, start, CountActual365); err == nil {
+		t.Error("If the start date doesn't precede the end date, it must return an error")
+	}
+}
+
+func TestMoneyWeightedReturn(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+	flows := []float64{10}
+	flowDates := []time.Time{time.Date(2020, time.January, 16, 0, 0, 0, 0, time.UTC)}
+	if got, _ := MoneyWeightedReturn(100, 115, start, end, flows, flowDates, 0.1); math.Abs(got-0.728424) > Precision {
+		t.Errorf("MoneyWeightedReturn(%f, %f, %v, %v, %v, %v, %f) = %f", 100.0, 115.0, start, end, flows, flowDates, 0.1, got)
+	}
+
+	if _, err := MoneyWeightedReturn(100, 115, start, end, flows, []time.Time{start}, 0.1); err == nil {
+		t.Error("If a flow doesn't occur after the start date, it must return an error")
+	}
+
+	if _, err := MoneyWeightedReturn(100, 115, start, end, flows, []time.Time{time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)}, 0.1); err == nil {
+		t.Error("If a flow occurs after the end date, it must return an error")
+	}
+
+	if _, err := MoneyWeightedReturn(100, 115, end, start, []float64{}, []time.Time{}, 0.1); err == nil {
+		t.Error("If the start date doesn't precede the end date, it must return an error")
+	}
+}
@@ -0,0 +1,105 @@
+package fin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	var tests = []struct {
+		x        float64
+		decimals int
+		mode     int
+		want     float64
+	}{
+		{2.675, 2, RoundHalfUp, 2.68},
+		{2.675, 2, RoundHalfEven, 2.68},
+		{2.665, 2, RoundHalfEven, 2.66},
+		{-2.665, 2, RoundHalfUp, -2.67},
+		{0.3185, 3, RoundHalfUp, 0.319},
+		{1.2345, 3, RoundDown, 1.234},
+		{-1.2345, 3, RoundFloor, -1.235},
+		{-1.2345, 3, RoundCeiling, -1.234},
+		{1.2341, 3, RoundUp, 1.235},
+		{1234.5, -1, RoundHalfEven, 1230},
+		{1e21, 2, RoundHalfEven, 1e21},
+	}
+
+	for _, test := range tests {
+		if got, err := Round(test.x, test.decimals, test.mode); err != nil || got != test.want {
+			t.Errorf("Round(%f, %d, %d) = %f, %v", test.x, test.decimals, test.mode, got, err)
+		}
+	}
+
+	if got, _ := Round(math.Inf(1), 2, RoundHalfEven); !math.IsInf(got, 1) {
+		t.Errorf("Round(+Inf) = %f", got)
+	}
+	if _, err := Round(1.5, 0, -1); err == nil {
+		t.Error("An invalid rounding mode should return an error")
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	var tests = []struct {
+		currency string
+		want     int
+	}{
+		{"USD", 2},
+		{"EUR", 2},
+		{"JPY", 0},
+		{"KWD", 3},
+		{"CLF", 4},
+		{"gbp", 2},
+	}
+
+	for _, test := range tests {
+		if got, err := MinorUnits(test.currency); err != nil || got != test.want {
+			t.Errorf("MinorUnits(%s) = %d, %v", test.currency, got, err)
+		}
+	}
+
+	if _, err := MinorUnits("XYZ"); err == nil {
+		t.Error("An unknown currency should return an error")
+	}
+}
+
+func TestScheduleRoundingModes(t *testing.T) {
+	// the periods are rounded with the mode, and the last one absorbs the residual
+	var tests = []struct {
+		mode int
+		want []string
+	}{
+		{RoundHalfEven, []string{"333", "333", "334"}},
+		{RoundUp, []string{"334", "334", "332"}},
+		{RoundDown, []string{"333", "333", "334"}},
+	}
+	units, _ := MinorUnits("JPY")
+	for _, test := range tests {
+		got, err := DepreciationScheduleStraightLine(NewDecimal(1000, 0), Decimal{}, 3, units, test.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range test.want {
+			if got[i].String() != test.want[i] {
+				t.Errorf("DepreciationScheduleStraightLine() with mode %d = %v, want %v", test.mode, got, test.want)
+				break
+			}
+		}
+	}
+
+	// a KWD loan rounded to fils, closing at zero
+	units, _ = MinorUnits("KWD")
+	schedule, err := AmortizationSchedule(decimal("0.004"), 12, decimal("1500"), Decimal{}, PayEnd, units, RoundHalfEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := schedule[0].Payment.String(); got != "-128.274" {
+		t.Errorf("AmortizationSchedule() payment = %s", got)
+	}
+	if got := schedule[11].Balance.String(); got != "0.000" {
+		t.Errorf("AmortizationSchedule() final balance = %s", got)
+	}
+	if _, err := AmortizationSchedule(decimal("0.004"), 12, decimal("1500"), Decimal{}, PayEnd, units, 9); err == nil {
+		t.Error("An invalid rounding mode should return an error")
+	}
+}
@@ -0,0 +1,158 @@
+package fin
+
+import (
+	"errors"
+	"time"
+)
+
+// scheduleDates returns the accrual dates of a schedule with the given number of periods per year, rolling backwards from maturity
+// so that an irregular (stub) period, if any, is the first one. The first date is start and the last one maturity.
+// If maturity is the last day of its month, so are all the generated dates.
+func scheduleDates(start time.Time, maturity time.Time, frequency int) ([]time.Time, error) {
+	if !isValidFrequency(frequency) {
+		return nil, errors.New("frequency must be 1, 2, 3, 4, 6 or 12")
+	}
+	start, maturity = civilDate(start), civilDate(maturity)
+	if !start.Before(maturity) {
+		return nil, errors.New("start must precede maturity")
+	}
+	endOfMonth := isEndOfMonth(maturity)
+	dates := []time.Time{maturity}
+	for k := 1; ; k++ {
+		date := addMonths(maturity, -k*12/frequency, endOfMonth)
+		if !date.After(start) {
+			break
+		}
+		dates = append(dates, date)
+	}
+	dates = append(dates, start)
+	for i, j := 0, len(dates)-1; i < j; i, j = i+1, j-1 {
+		dates[i], dates[j] = dates[j], dates[i]
+	}
+	return dates, nil
+}
+
+// addMonths adds months to date, clamping the day to the length of the resulting month.
+// If endOfMonth is true, the result is always the last day of its month.
+func addMonths(date time.Time, months int, endOfMonth bool) time.Time {
+	y, m, d := date.Date()
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	last := daysInMonth(first.Year(), first.Month())
+	if endOfMonth || d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, date.Location())
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func isEndOfMonth(date time.Time) bool {
+	return date.Day() == daysInMonth(date.Year(), date.Month())
+}
+
+func isValidFrequency(frequency int) bool {
+	return frequency > 0 && frequency <= 12 && 12%frequency == 0
+}
+
+// These constants are used for adjusting dates that fall on non-business days (parameter "convention"):
+const (
+	// The date isn't adjusted
+	BusinessDayUnadjusted = iota
+	// The first following business day
+	BusinessDayFollowing
+	// The first following business day, unless it falls in the next month, in which case it's the first preceding business day
+	BusinessDayModifiedFollowing
+	// The first preceding business day
+	BusinessDayPreceding
+	// The first preceding business day, unless it falls in the previous month, in which case it's the first following business day
+	BusinessDayModifiedPreceding
+)
+
+// Calendar determines the business days: every day except weekends and the calendar's holidays.
+// The zero value is a calendar without holidays.
+type Calendar struct {
+	holidays map[time.Time]bool
+}
+
+// NewCalendar returns a calendar with the given holidays.
+func NewCalendar(holidays ...time.Time) Calendar {
+	calendar := Calendar{holidays: make(map[time.Time]bool)}
+	for _, holiday := range holidays {
+		calendar.holidays[civilDate(holiday)] = true
+	}
+	return calendar
+}
+
+// IsBusinessDay returns whether date is neither a weekend nor a holiday.
+func (c Calendar) IsBusinessDay(date time.Time) bool {
+	date = civilDate(date)
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[date]
+}
+
+// Adjust returns the business day corresponding to date according to a business day convention (see the BusinessDay* constants).
+func (c Calendar) Adjust(date time.Time, convention int) time.Time {
+	date = civilDate(date)
+	switch convention {
+	case BusinessDayFollowing:
+		return c.roll(date, 1)
+	case BusinessDayModifiedFollowing:
+		if adjusted := c.roll(date, 1); adjusted.Month() == date.Month() {
+			return adjusted
+		}
+		return c.roll(date, -1)
+	case BusinessDayPreceding:
+		return c.roll(date, -1)
+	case BusinessDayModifiedPreceding:
+		if adjusted := c.roll(date, -1); adjusted.Month() == date.Month() {
+			return adjusted
+		}
+		return c.roll(date, 1)
+	}
+	return date
+}
+
+// AddBusinessDays returns the date n business days after date (before it, if n is negative).
+func (c Calendar) AddBusinessDays(date time.Time, n int) time.Time {
+	date = civilDate(date)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		date = date.AddDate(0, 0, step)
+		if c.IsBusinessDay(date) {
+			n--
+		}
+	}
+	return date
+}
+
+// roll moves date one day at a time in the given direction until it's a business day
+func (c Calendar) roll(date time.Time, step int) time.Time {
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date
+}
+
+// GenerateSchedule returns the dates of a schedule with the given number of periods per year (frequency) between start and maturity,
+// adjusted with calendar according to a business day convention (see the BusinessDay* constants).
+// Dates are rolled backwards from maturity, so an irregular (stub) period, if any, is the first one.
+func GenerateSchedule(start time.Time, maturity time.Time, frequency int, calendar Calendar, convention int) ([]time.Time, error) {
+	if convention < BusinessDayUnadjusted || convention > BusinessDayModifiedPreceding {
+		return nil, errors.New("invalid business day convention")
+	}
+	dates, err := scheduleDates(start, maturity, frequency)
+	if err != nil {
+		return nil, err
+	}
+	for i, date := range dates {
+		dates[i] = calendar.Adjust(date, convention)
+	}
+	return dates, nil
+}
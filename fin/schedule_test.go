@@ -0,0 +1,97 @@
+package fin
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendarAdjust(t *testing.T) {
+	calendar := NewCalendar(date(2020, time.June, 1))
+	var tests = []struct {
+		date       time.Time
+		convention int
+		want       time.Time
+	}{
+		{date(2020, time.January, 4), BusinessDayUnadjusted, date(2020, time.January, 4)},
+		{date(2020, time.January, 4), BusinessDayFollowing, date(2020, time.January, 6)},
+		{date(2020, time.January, 4), BusinessDayPreceding, date(2020, time.January, 3)},
+		{date(2020, time.May, 30), BusinessDayFollowing, date(2020, time.June, 2)},
+		{date(2020, time.May, 30), BusinessDayModifiedFollowing, date(2020, time.May, 29)},
+		{date(2020, time.February, 1), BusinessDayModifiedPreceding, date(2020, time.February, 3)},
+		{date(2020, time.January, 6), BusinessDayFollowing, date(2020, time.January, 6)},
+	}
+
+	for _, test := range tests {
+		if got := calendar.Adjust(test.date, test.convention); !got.Equal(test.want) {
+			t.Errorf("Calendar.Adjust(%v, %d) = %v", test.date, test.convention, got)
+		}
+	}
+}
+
+func TestCalendarAddBusinessDays(t *testing.T) {
+	calendar := NewCalendar(date(2020, time.June, 1))
+	var tests = []struct {
+		date time.Time
+		n    int
+		want time.Time
+	}{
+		{date(2020, time.May, 28), 2, date(2020, time.June, 2)},
+		{date(2020, time.June, 2), -2, date(2020, time.May, 28)},
+		{date(2020, time.June, 2), 0, date(2020, time.June, 2)},
+	}
+
+	for _, test := range tests {
+		if got := calendar.AddBusinessDays(test.date, test.n); !got.Equal(test.want) {
+			t.Errorf("Calendar.AddBusinessDays(%v, %d) = %v", test.date, test.n, got)
+		}
+	}
+
+	if (Calendar{}).IsBusinessDay(date(2020, time.June, 6)) {
+		t.Error("Saturdays shouldn't be business days")
+	}
+}
+
+func TestGenerateSchedule(t *testing.T) {
+	var tests = []struct {
+		start      time.Time
+		maturity   time.Time
+		frequency  int
+		convention int
+		want       []time.Time
+	}{
+		{date(2020, time.January, 15), date(2021, time.January, 15), 2, BusinessDayUnadjusted, []time.Time{date(2020, time.January, 15), date(2020, time.July, 15), date(2021, time.January, 15)}},
+		// short first period
+		{date(2020, time.March, 1), date(2021, time.January, 15), 2, BusinessDayUnadjusted, []time.Time{date(2020, time.March, 1), date(2020, time.July, 15), date(2021, time.January, 15)}},
+		// end of month maturity
+		{date(2020, time.February, 29), date(2020, time.August, 31), 4, BusinessDayUnadjusted, []time.Time{date(2020, time.February, 29), date(2020, time.May, 31), date(2020, time.August, 31)}},
+		{date(2020, time.February, 29), date(2020, time.August, 31), 4, BusinessDayModifiedFollowing, []time.Time{date(2020, time.February, 28), date(2020, time.May, 29), date(2020, time.August, 31)}},
+	}
+
+	for _, test := range tests {
+		got, err := GenerateSchedule(test.start, test.maturity, test.frequency, Calendar{}, test.convention)
+		if err != nil || len(got) != len(test.want) {
+			t.Errorf("GenerateSchedule(%v, %v, %d, %d) = %v, %v", test.start, test.maturity, test.frequency, test.convention, got, err)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(test.want[i]) {
+				t.Errorf("GenerateSchedule(%v, %v, %d, %d) = %v", test.start, test.maturity, test.frequency, test.convention, got)
+				break
+			}
+		}
+	}
+
+	if _, err := GenerateSchedule(date(2020, time.January, 15), date(2021, time.January, 15), 5, Calendar{}, BusinessDayUnadjusted); err == nil {
+		t.Error("An invalid frequency should return an error")
+	}
+	if _, err := GenerateSchedule(date(2021, time.January, 15), date(2020, time.January, 15), 2, Calendar{}, BusinessDayUnadjusted); err == nil {
+		t.Error("If start doesn't precede maturity, it must return an error")
+	}
+	if _, err := GenerateSchedule(date(2020, time.January, 15), date(2021, time.January, 15), 2, Calendar{}, 9); err == nil {
+		t.Error("An invalid business day convention should return an error")
+	}
+}
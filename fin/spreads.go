@@ -0,0 +1,130 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// PriceCurve returns the clean price of the bond at settlement, discounting its cash flows with curve, whose reference date is taken to be settlement.
+func (b FixedRateBond) PriceCurve(settlement time.Time, curve DiscountCurve) (float64, error) {
+	dates, amounts, err := b.CashFlows(settlement)
+	if err != nil {
+		return 0, err
+	}
+	accrued, err := b.AccruedInterest(settlement)
+	if err != nil {
+		return 0, err
+	}
+	price, err := ScheduledNetPresentValueCurve(curve, append([]float64{0}, amounts...), append([]time.Time{civilDate(settlement)}, dates...))
+	if err != nil {
+		return 0, err
+	}
+	return price - accrued, nil
+}
+
+// ZSpread returns the constant spread that, added to the zero rates of curve (compounded with the bond's frequency), discounts the bond's cash flows to the given clean price.
+// The curve's reference date is taken to be settlement.
+func (b FixedRateBond) ZSpread(settlement time.Time, curve DiscountCurve, price float64) (float64, error) {
+	if price <= 0 {
+		return 0, errors.New("price must be positive")
+	}
+	dates, _, err := b.CashFlows(settlement)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := b.PriceCurve(settlement, curve); err != nil {
+		return 0, err
+	}
+	// the discount factors are only defined while the spread keeps every compounded growth rate positive
+	f, minimum := float64(b.Frequency), math.Inf(-1)
+	for _, date := range dates {
+		if t := curveTime(civilDate(settlement), date); t > 0 {
+			minimum = math.Max(minimum, -f*math.Pow(curve.DiscountFactor(t), -1/(f*t)))
+		}
+	}
+	var invalid error
+	function := func(spread float64) float64 {
+		p, _ := b.PriceCurve(settlement, spreadCurve{curve: curve, spread: spread, frequency: b.Frequency})
+		if math.IsNaN(p) || math.IsInf(p, 0) {
+			invalid = errors.New("the spread makes the discount factors undefined")
+		}
+		return p - price
+	}
+
+	// the price decreases with the spread, and grows without bound as it approaches the minimum
+	low, high := -0.01, 0.01
+	if high <= minimum {
+		high = minimum + 0.02
+	}
+	if low <= minimum {
+		low = minimum + (high-minimum)/2
+	}
+	fLow, fHigh := function(low), function(high)
+	for i := 0; fLow*fHigh > 0; i++ {
+		if i == MaxIterations {
+			return 0, errors.New("couldn't bracket the Z-spread")
+		}
+		if fLow < 0 {
+			low, high, fHigh = minimum+(low-minimum)/2, low, fLow
+			fLow = function(low)
+		} else {
+			low, high, fLow = high, high+1.6*(high-low), fHigh
+			fHigh = function(high)
+		}
+	}
+	spread, err := brent(function, low, high)
+	if err != nil {
+		return 0, err
+	}
+	if invalid != nil {
+		return 0, invalid
+	}
+	return spread, nil
+}
+
+// GSpread returns the difference between the bond's yield to maturity at the given clean price and the government bond yield for the same maturity,
+// linearly interpolated from the yields of the government bonds maturing at the given times (in years from settlement).
+// The yields are expected to be quoted with the bond's compounding frequency.
+func (b FixedRateBond) GSpread(settlement time.Time, price float64, times []float64, yields []float64) (float64, error) {
+	return b.interpolatedSpread(settlement, price, times, yields)
+}
+
+// ISpread returns the difference between the bond's yield to maturity at the given clean price and the swap rate for the same maturity,
+// linearly interpolated from the par swap rates for the given tenors (in years).
+// The rates are expected to be quoted with the bond's compounding frequency.
+func (b FixedRateBond) ISpread(settlement time.Time, price float64, tenors []float64, swapRates []float64) (float64, error) {
+	return b.interpolatedSpread(settlement, price, tenors, swapRates)
+}
+
+// interpolatedSpread returns the difference between the bond's yield and the benchmark rate for its maturity, linearly interpolated from rates at the given times
+func (b FixedRateBond) interpolatedSpread(settlement time.Time, price float64, times []float64, rates []float64) (float64, error) {
+	if err := validateCurvePoints(times, rates); err != nil {
+		return 0, err
+	}
+	yield, err := b.Yield(settlement, price)
+	if err != nil {
+		return 0, err
+	}
+	return yield - linearInterpolation(times, rates, curveTime(settlement, b.Maturity)), nil
+}
+
+// spreadCurve is a term structure whose zero rates, compounded frequency times per year, are those of curve plus spread.
+// The discount factor is +Inf where the spread makes the growth rate non-positive; ZSpread keeps the spread above that bound and reports an error otherwise.
+type spreadCurve struct {
+	curve     DiscountCurve
+	spread    float64
+	frequency int
+}
+
+func (c spreadCurve) DiscountFactor(t float64) float64 {
+	if t <= 0 {
+		return c.curve.DiscountFactor(t)
+	}
+	f := float64(c.frequency)
+	growth := math.Pow(c.curve.DiscountFactor(t), -1/(f*t)) + c.spread/f
+	if growth <= 0 {
+		return math.Inf(1)
+	}
+	return math.Pow(growth, -f*t)
+}
@@ -0,0 +1,85 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFixedRateBondPriceCurve(t *testing.T) {
+	curve := FlatCurve{Rate: 0.05}
+	bond := FixedRateBond{Maturity: date(2022, time.January, 15), Coupon: 0.06, Frequency: 1, Basis: CountActualActual, Redemption: 100}
+	settlement := date(2020, time.July, 15)
+	// coupons paid in 184 and 549 days, and 182 days of accrued interest
+	want := 6*math.Pow(1.05, -184.0/365) + 106*math.Pow(1.05, -549.0/365) - 6*182.0/366
+	if got, err := bond.PriceCurve(settlement, curve); err != nil || math.Abs(want-got) > Precision {
+		t.Errorf("%v.PriceCurve(%v, %v) = %f, %v, want %f", bond, settlement, curve, got, err, want)
+	}
+}
+
+func TestFixedRateBondZSpread(t *testing.T) {
+	curve, err := BootstrapCurve(curveReference, curveQuotes, InterpolationMonotoneConvex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bond := FixedRateBond{Maturity: date(2027, time.March, 1), Coupon: 0.045, Frequency: 2, Basis: CountNasd, Redemption: 100}
+	for _, spread := range []float64{-0.002, 0, 0.0125, 0.04} {
+		price, err := bond.PriceCurve(curveReference, spreadCurve{curve: curve, spread: spread, frequency: bond.Frequency})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := bond.ZSpread(curveReference, curve, price); err != nil || math.Abs(spread-got) > Precision {
+			t.Errorf("%v.ZSpread(%v, %f) = %f, %v, want %f", bond, curveReference, price, got, err, spread)
+		}
+	}
+
+	// a price far above par needs a spread close to the lowest one keeping the discount factors defined
+	for _, price := range []float64{400, 2000} {
+		got, err := bond.ZSpread(curveReference, curve, price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repriced, _ := bond.PriceCurve(curveReference, spreadCurve{curve: curve, spread: got, frequency: bond.Frequency}); math.Abs(price-repriced) > Precision {
+			t.Errorf("%v.ZSpread(%v, %f) = %f, which reprices to %f", bond, curveReference, price, got, repriced)
+		}
+	}
+	// and the search must stay above that spread when it lies close to zero
+	extreme := FlatCurve{Rate: -0.99999}
+	extremePrice, _ := bond.PriceCurve(curveReference, spreadCurve{curve: extreme, spread: 0.002, frequency: bond.Frequency})
+	if got, err := bond.ZSpread(curveReference, extreme, extremePrice); err != nil || math.Abs(0.002-got) > Precision {
+		t.Errorf("%v.ZSpread(%v, %f) = %f, %v, want %f", bond, curveReference, extremePrice, got, err, 0.002)
+	}
+	if _, err := bond.ZSpread(curveReference, curve, 0); err == nil {
+		t.Error("A zero price should return an error")
+	}
+
+	// over a flat curve compounded with the bond's frequency, the Z-spread is the yield spread when the cash flows fall on whole periods
+	bond = FixedRateBond{Maturity: date(2030, time.January, 1), Coupon: 0.05, Frequency: 1, Basis: CountActual365, Redemption: 100}
+	settlement := date(2029, time.January, 1)
+	price, _ := bond.Price(settlement, 0.06)
+	if got, err := bond.ZSpread(settlement, FlatCurve{Rate: 0.04}, price); err != nil || math.Abs(0.02-got) > Precision {
+		t.Errorf("%v.ZSpread(%v, %f) = %f, %v, want %f", bond, settlement, price, got, err, 0.02)
+	}
+}
+
+func TestFixedRateBondGSpread(t *testing.T) {
+	bond := FixedRateBond{Maturity: date(2027, time.January, 15), Coupon: 0.05, Frequency: 2, Basis: CountActualActual, Redemption: 100}
+	settlement := date(2020, time.January, 15)
+	price, _ := bond.Price(settlement, 0.05)
+	// 7.005479 years to maturity
+	if got, err := bond.GSpread(settlement, price, []float64{2, 5, 10}, []float64{0.03, 0.035, 0.04}); err != nil || math.Abs(0.012995-got) > Precision {
+		t.Errorf("%v.GSpread(%v, %f) = %f, %v", bond, settlement, price, got, err)
+	}
+	if _, err := bond.GSpread(settlement, price, []float64{5, 2}, []float64{0.03, 0.035}); err == nil {
+		t.Error("Unordered times should return an error")
+	}
+}
+
+func TestFixedRateBondISpread(t *testing.T) {
+	bond := FixedRateBond{Maturity: date(2027, time.January, 15), Coupon: 0.05, Frequency: 2, Basis: CountActualActual, Redemption: 100}
+	settlement := date(2020, time.January, 15)
+	price, _ := bond.Price(settlement, 0.05)
+	if got, err := bond.ISpread(settlement, price, []float64{1, 3, 7, 10}, []float64{0.04, 0.041, 0.043, 0.045}); err != nil || math.Abs(0.006996-got) > Precision {
+		t.Errorf("%v.ISpread(%v, %f) = %f, %v", bond, settlement, price, got, err)
+	}
+}
@@ -0,0 +1,145 @@
+package fin
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// These constants are used in the Swap type (field "Type"), for specifying which leg is paid:
+const (
+	// Pays the fixed leg and receives the floating leg
+	SwapPayer = iota
+	// Receives the fixed leg and pays the floating leg
+	SwapReceiver
+)
+
+// Swap is a vanilla interest rate swap, exchanging fixed payments for floating payments on the same notional.
+//
+// The leg schedules are generated between Start and Maturity with their frequencies (payments per year), and adjusted with Calendar according to Convention
+// (see the BusinessDay* constants). Accrual fractions are measured with each leg's daycount basis (see the Count* constants).
+//
+// The methods taking curves value the swap at valuation, which is taken as the curves' reference date. Only the payments after valuation are considered.
+type Swap struct {
+	Type           int
+	Notional       float64
+	FixedRate      float64
+	FixedFrequency int
+	FixedBasis     int
+	// Spread is added to the floating rate
+	Spread         float64
+	FloatFrequency int
+	FloatBasis     int
+	// CurrentFixing is the floating rate (without the spread) fixed at the start of the floating period in progress at valuation, if any.
+	// The rates of the periods starting on or after valuation are projected from the forward curve.
+	CurrentFixing float64
+	Start         time.Time
+	Maturity      time.Time
+	Calendar      Calendar
+	Convention    int
+}
+
+// FixedLegValue returns the present value of the fixed leg's payments.
+func (s Swap) FixedLegValue(discount DiscountCurve, valuation time.Time) (float64, error) {
+	annuity, err := s.Annuity(discount, valuation)
+	if err != nil {
+		return 0, err
+	}
+	return s.Notional * s.FixedRate * annuity, nil
+}
+
+// FloatLegValue returns the present value of the floating leg's payments, whose rates are projected from the forward curve (plus the spread)
+// and discounted with the discount curve. Both can be the same curve. The period in progress at valuation pays CurrentFixing (plus the spread).
+func (s Swap) FloatLegValue(discount DiscountCurve, forward DiscountCurve, valuation time.Time) (float64, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	dates, err := GenerateSchedule(s.Start, s.Maturity, s.FloatFrequency, s.Calendar, s.Convention)
+	if err != nil {
+		return 0, err
+	}
+	value := 0.0
+	for i := 1; i < len(dates); i++ {
+		if !dates[i].After(civilDate(valuation)) {
+			continue
+		}
+		rate := s.CurrentFixing
+		if !dates[i-1].Before(civilDate(valuation)) {
+			rate = (forward.DiscountFactor(curveTime(valuation, dates[i-1]))/forward.DiscountFactor(curveTime(valuation, dates[i])) - 1) / yearFraction(dates[i-1], dates[i], s.FloatBasis)
+		}
+		value += (rate + s.Spread) * yearFraction(dates[i-1], dates[i], s.FloatBasis) * discount.DiscountFactor(curveTime(valuation, dates[i]))
+	}
+	return s.Notional * value, nil
+}
+
+// Annuity returns the present value of receiving one unit per year on the fixed leg's schedule, that is the sum of its accrual fractions times their discount factors.
+func (s Swap) Annuity(discount DiscountCurve, valuation time.Time) (float64, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	dates, err := GenerateSchedule(s.Start, s.Maturity, s.FixedFrequency, s.Calendar, s.Convention)
+	if err != nil {
+		return 0, err
+	}
+	annuity := 0.0
+	for i := 1; i < len(dates); i++ {
+		if dates[i].After(civilDate(valuation)) {
+			annuity += yearFraction(dates[i-1], dates[i], s.FixedBasis) * discount.DiscountFactor(curveTime(valuation, dates[i]))
+		}
+	}
+	return annuity, nil
+}
+
+// ParRate returns the fixed rate that makes the swap's value zero.
+func (s Swap) ParRate(discount DiscountCurve, forward DiscountCurve, valuation time.Time) (float64, error) {
+	float, err := s.FloatLegValue(discount, forward, valuation)
+	if err != nil {
+		return 0, err
+	}
+	annuity, err := s.Annuity(discount, valuation)
+	if err != nil {
+		return 0, err
+	}
+	if annuity == 0 {
+		return 0, errors.New("the swap has no remaining fixed payments")
+	}
+	return float / s.Notional / annuity, nil
+}
+
+// NetPresentValue returns the value of the swap: the floating leg minus the fixed leg for a payer swap, and the opposite for a receiver swap.
+func (s Swap) NetPresentValue(discount DiscountCurve, forward DiscountCurve, valuation time.Time) (float64, error) {
+	fixed, err := s.FixedLegValue(discount, valuation)
+	if err != nil {
+		return 0, err
+	}
+	float, err := s.FloatLegValue(discount, forward, valuation)
+	if err != nil {
+		return 0, err
+	}
+	if s.Type == SwapPayer {
+		return float - fixed, nil
+	}
+	return fixed - float, nil
+}
+
+// PV01 returns the change in the swap's value when the fixed rate changes by one basis point (0.01%), in absolute value.
+func (s Swap) PV01(discount DiscountCurve, valuation time.Time) (float64, error) {
+	annuity, err := s.Annuity(discount, valuation)
+	if err != nil {
+		return 0, err
+	}
+	return math.Abs(s.Notional) * annuity * 0.0001, nil
+}
+
+func (s Swap) validate() error {
+	if s.Type != SwapPayer && s.Type != SwapReceiver {
+		return errors.New("swap type must be payer or receiver")
+	}
+	if s.Notional == 0 {
+		return errors.New("notional can't be zero")
+	}
+	if !isValidBasis(s.FixedBasis) || !isValidBasis(s.FloatBasis) {
+		return errors.New("invalid day count basis")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package fin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSwap(t *testing.T) {
+	curve, _ := NewPiecewiseConstantCurve([]float64{1}, []float64{0.03})
+	valuation := date(2020, time.January, 1)
+	swap := Swap{
+		Type:           SwapPayer,
+		Notional:       1e6,
+		FixedRate:      0.025,
+		FixedFrequency: 1,
+		FixedBasis:     CountActual365,
+		FloatFrequency: 4,
+		FloatBasis:     CountActual360,
+		Start:          valuation,
+		Maturity:       date(2022, time.January, 1),
+	}
+
+	if got, _ := swap.Annuity(curve, valuation); math.Abs(got-1.914711) > Precision {
+		t.Errorf("Swap.Annuity() = %f", got)
+	}
+	if got, _ := swap.FixedLegValue(curve, valuation); math.Abs(got-47867.786050) > Precision {
+		t.Errorf("Swap.FixedLegValue() = %f", got)
+	}
+	// with a single curve, the floating leg is worth the notional minus its discounted repayment
+	if got, _ := swap.FloatLegValue(curve, curve, valuation); math.Abs(got-1e6*(1-math.Exp(-0.03*731/365))) > Precision {
+		t.Errorf("Swap.FloatLegValue() = %f", got)
+	}
+	if got, _ := swap.ParRate(curve, curve, valuation); math.Abs(got-0.030455) > Precision {
+		t.Errorf("Swap.ParRate() = %f", got)
+	}
+	if got, _ := swap.NetPresentValue(curve, curve, valuation); math.Abs(got-10445.082489) > Precision {
+		t.Errorf("Swap.NetPresentValue() = %f", got)
+	}
+	if got, _ := swap.PV01(curve, valuation); math.Abs(got-191.471144) > Precision {
+		t.Errorf("Swap.PV01() = %f", got)
+	}
+
+	receiver := swap
+	receiver.Type = SwapReceiver
+	if got, _ := receiver.NetPresentValue(curve, curve, valuation); math.Abs(got+10445.082489) > Precision {
+		t.Errorf("Swap.NetPresentValue() for a receiver swap = %f", got)
+	}
+
+	// only the remaining payments are valued
+	later := date(2021, time.March, 1)
+	if got, _ := swap.Annuity(curve, later); math.Abs(got-curve.DiscountFactor(curveTime(later, date(2022, time.January, 1)))) > Precision {
+		t.Errorf("Swap.Annuity() after the first payment = %f", got)
+	}
+
+	invalid := swap
+	invalid.Notional = 0
+	if _, err := invalid.ParRate(curve, curve, valuation); err == nil {
+		t.Error("A zero notional should return an error")
+	}
+	invalid = swap
+	invalid.FixedBasis = 7
+	if _, err := invalid.NetPresentValue(curve, curve, valuation); err == nil {
+		t.Error("An invalid basis should return an error")
+	}
+	invalid = swap
+	invalid.Type = 3
+	if _, err := invalid.NetPresentValue(curve, curve, valuation); err == nil {
+		t.Error("An invalid swap type should return an error")
+	}
+}
+
+func TestSeasonedSwap(t *testing.T) {
+	curve, _ := NewPiecewiseConstantCurve([]float64{1}, []float64{0.03})
+	swap := Swap{
+		Type:           SwapPayer,
+		Notional:       1e6,
+		FixedRate:      0.025,
+		FixedFrequency: 1,
+		FixedBasis:     CountActual365,
+		FloatFrequency: 4,
+		FloatBasis:     CountActual360,
+		CurrentFixing:  0.045,
+		Start:          date(2019, time.November, 1),
+		Maturity:       date(2021, time.November, 1),
+	}
+	// the current period, from November 1 to February 1, pays the fixing on its full accrual,
+	// and the remaining ones are worth the notional at the next payment minus its discounted repayment
+	valuation := date(2020, time.January, 1)
+	next, maturity := curveTime(valuation, date(2020, time.February, 1)), curveTime(valuation, date(2021, time.November, 1))
+	want := 1e6 * ((1+0.045*92/360)*curve.DiscountFactor(next) - curve.DiscountFactor(maturity))
+	if got, err := swap.FloatLegValue(curve, curve, valuation); err != nil || math.Abs(got-want) > Precision {
+		t.Errorf("Swap.FloatLegValue() for a seasoned swap = %f, %v, want %f", got, err, want)
+	}
+
+	// a period starting at valuation is projected, not fixed
+	if got, _ := swap.FloatLegValue(curve, curve, date(2020, time.February, 1)); math.Abs(got-1e6*(1-curve.DiscountFactor(curveTime(date(2020, time.February, 1), date(2021, time.November, 1))))) > Precision {
+		t.Errorf("Swap.FloatLegValue() at a reset date = %f", got)
+	}
+}
+
+func TestSwapParRateOnBootstrappedCurve(t *testing.T) {
+	curve, _ := BootstrapCurve(curveReference, curveQuotes, InterpolationMonotoneConvex)
+	for _, quote := range curveQuotes {
+		quote, ok := quote.(SwapQuote)
+		if !ok {
+			continue
+		}
+		swap := Swap{
+			Notional:       1,
+			FixedFrequency: quote.Frequency,
+			FixedBasis:     quote.Basis,
+			FloatFrequency: 4,
+			FloatBasis:     CountActual360,
+			Start:          curveReference,
+			Maturity:       quote.Maturity,
+		}
+		if got, _ := swap.ParRate(curve, curve, curveReference); math.Abs(got-quote.Rate) > 1e-10 {
+			t.Errorf("Swap.ParRate() = %f, want %f", got, quote.Rate)
+		}
+	}
+}